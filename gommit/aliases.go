@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// scopeAlias returns the canonical form of a scope element. An alias applies
+// to the whole element or to its leading path, so that with frontend→ui,
+// "frontend/forms" becomes "ui/forms". The longest matching alias wins.
+func scopeAlias(aliases map[string]string, element string) (string, bool) {
+	best := ""
+	for alias := range aliases {
+		if (element == alias || strings.HasPrefix(element, alias+scopePathSeparator)) && len(alias) > len(best) {
+			best = alias
+		}
+	}
+	if best == "" {
+		return "", false
+	}
+	return aliases[best] + element[len(best):], true
+}
+
+func checkTypeAlias(commit *ParsedCommit, config Config) []Violation {
+	canonical, ok := config.TypeAliases[commit.Type]
+	if !ok {
+		return nil
+	}
+	fixed := canonical + commit.Header[len(commit.Type):]
+	return []Violation{{
+		Line: 1, Column: 1, EndColumn: len(commit.Type) + 1,
+		Message: fmt.Sprintf("Type '%s' is an alias of '%s'", commit.Type, canonical),
+		Fix: &Fix{
+			Description: fmt.Sprintf("Change type '%s' to '%s'", commit.Type, canonical),
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		},
+	}}
+}
+
+func checkScopeAlias(commit *ParsedCommit, config Config) []Violation {
+	if !commit.HasScope {
+		return nil
+	}
+
+	var violations []Violation
+	for _, element := range splitScopes(commit.Scope, commit.ScopeOffset) {
+		canonical, ok := scopeAlias(config.ScopeAliases, element.Value)
+		if !ok {
+			continue
+		}
+		start := element.Offset + 1
+		fixed := commit.Header[:element.Offset] + canonical + commit.Header[element.Offset+len(element.Value):]
+		violations = append(violations, Violation{
+			Line: 1, Column: start, EndColumn: start + len(element.Value),
+			Message: fmt.Sprintf("Scope '%s' is an alias of '%s'", element.Value, canonical),
+			Fix: &Fix{
+				Description: fmt.Sprintf("Change scope '%s' to '%s'", element.Value, canonical),
+				Apply: func(msg string) string {
+					return replaceLine(msg, 1, fixed)
+				},
+			},
+		})
+	}
+	return violations
+}
+
+// isAliased reports whether value is left to the given alias rule, so that
+// the enum rules do not report it a second time.
+func isAliased(config Config, ruleName string, aliased bool) bool {
+	return aliased && ruleSeverity(config, ruleName) != SeverityOff
+}
+
+// validateAliases checks that aliases point to allowed types and scopes.
+func validateAliases(config Config) error {
+	for _, alias := range sortedKeys(config.TypeAliases) {
+		if target := config.TypeAliases[alias]; !contains(config.AllowedTypes, target) {
+			return fmt.Errorf("type alias %q points to %q, which is not an allowed type", alias, target)
+		}
+	}
+	if len(config.AllowedScopes) == 0 {
+		return nil
+	}
+	for _, alias := range sortedKeys(config.ScopeAliases) {
+		if target := config.ScopeAliases[alias]; !isScopeAllowed(config.AllowedScopes, target) {
+			return fmt.Errorf("scope alias %q points to %q, which is not an allowed scope", alias, target)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckAliases(t *testing.T) {
+	config := defaultConfig
+	config.TypeAliases = map[string]string{"feature": "feat", "bugfix": "fix", "doc": "docs"}
+	config.ScopeAliases = map[string]string{"frontend": "ui", "frontend/legacy": "ui/compat"}
+
+	tests := []struct {
+		name     string
+		msg      string
+		check    checkFunc
+		expected []string
+		fixed    string
+	}{
+		{
+			name:  "Canonical type",
+			msg:   "feat: add parser",
+			check: checkTypeAlias,
+		},
+		{
+			name:     "Type alias",
+			msg:      "bugfix(api): handle nil",
+			check:    checkTypeAlias,
+			expected: []string{"Type 'bugfix' is an alias of 'fix'"},
+			fixed:    "fix(api): handle nil",
+		},
+		{
+			name:     "Scope alias in a multi-scope",
+			msg:      "feat(api, frontend): add login",
+			check:    checkScopeAlias,
+			expected: []string{"Scope 'frontend' is an alias of 'ui'"},
+			fixed:    "feat(api, ui): add login",
+		},
+		{
+			name:     "Scope alias on a leading path",
+			msg:      "fix(frontend/forms): validate email",
+			check:    checkScopeAlias,
+			expected: []string{"Scope 'frontend/forms' is an alias of 'ui/forms'"},
+			fixed:    "fix(ui/forms): validate email",
+		},
+		{
+			name:     "Longest scope alias wins",
+			msg:      "fix(frontend/legacy/ie): drop polyfills",
+			check:    checkScopeAlias,
+			expected: []string{"Scope 'frontend/legacy/ie' is an alias of 'ui/compat/ie'"},
+			fixed:    "fix(ui/compat/ie): drop polyfills",
+		},
+		{
+			name:  "Aliased type is not reported by type-enum",
+			msg:   "feature: add parser",
+			check: checkTypeEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := tt.check(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("check() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+
+	config.Rules = map[string]Severity{"type-alias": SeverityOff}
+	if violations := checkTypeEnum(parseCommit("feature: add parser", ": "), config); len(violations) != 1 {
+		t.Errorf("checkTypeEnum() with type-alias off = %v, want one violation", violations)
+	}
+}
+
+func TestApplyAliasFixes(t *testing.T) {
+	config := defaultConfig
+	config.TypeAliases = map[string]string{"feature": "feat"}
+	config.ScopeAliases = map[string]string{"frontend": "ui"}
+
+	fixed, applied := applyFixes("feature(frontend): Add login.", config, "type-alias", "scope-alias")
+	if expected := "feat(ui): Add login."; fixed != expected {
+		t.Errorf("applyFixes() = %q, want %q", fixed, expected)
+	}
+	if len(applied) != 2 {
+		t.Errorf("applyFixes() applied %v, want the two alias fixes", applied)
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(*Config)
+		wantErr bool
+	}{
+		{
+			name:   "Valid aliases",
+			config: func(c *Config) { c.TypeAliases = map[string]string{"feature": "feat"} },
+		},
+		{
+			name:    "Type alias to an unknown type",
+			config:  func(c *Config) { c.TypeAliases = map[string]string{"feature": "feature-request"} },
+			wantErr: true,
+		},
+		{
+			name: "Scope alias without allowed scopes",
+			config: func(c *Config) {
+				c.ScopeAliases = map[string]string{"frontend": "ui"}
+			},
+		},
+		{
+			name: "Scope alias to an unknown scope",
+			config: func(c *Config) {
+				c.AllowedScopes = []ScopeNode{{Name: "api"}}
+				c.ScopeAliases = map[string]string{"frontend": "ui"}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig
+			tt.config(&config)
+			if err := validateAliases(config); (err != nil) != tt.wantErr {
+				t.Errorf("validateAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
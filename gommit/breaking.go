@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// breakingChangeTokens are the spellings the Conventional Commits
+// specification allows for the breaking change footer token.
+var breakingChangeTokens = []string{"BREAKING CHANGE", "BREAKING-CHANGE"}
+
+// breakingChangeToken returns the token used when adding a breaking change
+// footer.
+func breakingChangeToken(config Config) string {
+	if config.BreakingChangeToken != "" {
+		return config.BreakingChangeToken
+	}
+	return breakingChangeTokens[0]
+}
+
+// breakingChangeFooters returns the breaking change footers of commit. Besides
+// the trailers git recognises, any line after the header starting with
+// "BREAKING CHANGE:" counts, as the Conventional Commits specification has
+// it: such a footer need not be in the last paragraph, and its value may run
+// over the following lines up to a blank line or the next footer.
+func breakingChangeFooters(commit *ParsedCommit) []Footer {
+	var footers []Footer
+	parsed := map[int]bool{}
+	for _, footer := range commit.Footers {
+		if isBreakingChangeToken(footer.Token) {
+			footers = append(footers, footer)
+			parsed[footer.Line] = true
+		}
+	}
+
+	for i := 1; i < len(commit.Lines); i++ {
+		line := commit.Lines[i]
+		token := breakingChangeLineToken(line)
+		if token == "" || parsed[i+1] {
+			continue
+		}
+		separator := ":"
+		if strings.HasPrefix(line[len(token)+1:], " ") {
+			separator = ": "
+		}
+		value := []string{strings.TrimSpace(line[len(token)+len(separator):])}
+		for _, next := range commit.Lines[i+1:] {
+			if isBlankLine(next) || footerStartPattern.MatchString(next) {
+				break
+			}
+			value = append(value, strings.TrimSpace(next))
+		}
+		footers = append(footers, Footer{
+			Token:     token,
+			Separator: separator,
+			Value:     strings.TrimSpace(strings.Join(value, "\n")),
+			Offset:    commit.lineOffset(i + 1),
+			Line:      i + 1,
+		})
+	}
+	sort.Slice(footers, func(a, b int) bool { return footers[a].Line < footers[b].Line })
+	return footers
+}
+
+func breakingChangeLineToken(line string) string {
+	for _, token := range breakingChangeTokens {
+		if strings.HasPrefix(line, token+":") {
+			return token
+		}
+	}
+	return ""
+}
+
+// checkBreakingChangeMarker reports a breaking change footer in a commit
+// whose header lacks the '!' marker, which tools reading only the header
+// would miss.
+func checkBreakingChangeMarker(commit *ParsedCommit, config Config) []Violation {
+	footers := breakingChangeFooters(commit)
+	if commit.Breaking || len(footers) == 0 {
+		return nil
+	}
+	v := Violation{
+		Line: footers[0].Line, Column: 1, EndColumn: len(footers[0].Token) + 1,
+		Message: "Breaking change footer requires a '!' before the separator in the header",
+	}
+	if commit.HeaderError == "" {
+		pos := commit.DescriptionOffset - len(headerSeparator(config))
+		fixed := commit.Header[:pos] + "!" + commit.Header[pos:]
+		v.Fix = &Fix{
+			Description: "Add '!' to the header",
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		}
+	}
+	return []Violation{v}
+}
+
+func checkBreakingChangeEmpty(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	for _, footer := range breakingChangeFooters(commit) {
+		if footer.Value != "" {
+			continue
+		}
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: 1, EndColumn: len(commit.Lines[footer.Line-1]) + 1,
+			Message: fmt.Sprintf("%s footer must describe the breaking change", footer.Token),
+		})
+	}
+	return violations
+}
+
+// checkBreakingChangeToken reports breaking change tokens that are not in
+// uppercase, or not spelled as breaking_change_token when it is set.
+func checkBreakingChangeToken(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		if !contains(breakingChangeTokens, strings.ToUpper(footer.Token)) {
+			continue
+		}
+		expected := config.BreakingChangeToken
+		if expected == "" {
+			expected = strings.ToUpper(footer.Token)
+		}
+		if footer.Token == expected {
+			continue
+		}
+		line := commit.Lines[footer.Line-1]
+		fixed := expected + line[len(footer.Token):]
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: 1, EndColumn: len(footer.Token) + 1,
+			Message: fmt.Sprintf("Breaking change footer must be spelled '%s' (found '%s')", expected, footer.Token),
+			Fix: &Fix{
+				Description: fmt.Sprintf("Change '%s' to '%s'", footer.Token, expected),
+				Apply: func(msg string) string {
+					return replaceLine(msg, footer.Line, fixed)
+				},
+			},
+		})
+	}
+	return violations
+}
+
+func validateBreakingChangeToken(config Config) error {
+	if config.BreakingChangeToken != "" && !contains(breakingChangeTokens, config.BreakingChangeToken) {
+		return fmt.Errorf("invalid breaking_change_token %q (expected %s)", config.BreakingChangeToken, strings.Join(breakingChangeTokens, " or "))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckBreakingChangeRules(t *testing.T) {
+	hyphenated := defaultConfig
+	hyphenated.BreakingChangeToken = "BREAKING-CHANGE"
+
+	tests := []struct {
+		name     string
+		msg      string
+		config   Config
+		check    checkFunc
+		expected []string
+		fixed    string
+	}{
+		{
+			name:  "Marker and footer",
+			msg:   "feat!: drop v1\n\nBREAKING CHANGE: v1 is gone",
+			check: checkBreakingChange,
+		},
+		{
+			name:     "Marker without footer",
+			msg:      "feat(api)!: drop v1",
+			check:    checkBreakingChange,
+			expected: []string{"Breaking change must be described in a BREAKING CHANGE footer"},
+		},
+		{
+			name:  "Exclamation mark in the description",
+			msg:   "fix: reject a != b in filters!",
+			check: checkBreakingChange,
+		},
+		{
+			name:  "Hyphenated footer",
+			msg:   "feat!: drop v1\n\nBREAKING-CHANGE: v1 is gone",
+			check: checkBreakingChange,
+		},
+		{
+			name:  "Footer value over several lines",
+			msg:   "feat!: drop node 12\n\nBREAKING CHANGE: node 12 is unsupported\nbecause it is EOL.",
+			check: checkBreakingChange,
+		},
+		{
+			name:  "Footer followed by another paragraph",
+			msg:   "feat!: drop node 12\n\nBREAKING CHANGE: node 12 is unsupported\n\nRefs: #12",
+			check: checkBreakingChange,
+		},
+		{
+			name:  "Footer value over several lines is not empty",
+			msg:   "feat!: drop node 12\n\nBREAKING CHANGE:\nnode 12 is unsupported.",
+			check: checkBreakingChangeEmpty,
+		},
+		{
+			name:     "Footer outside the trailers without marker",
+			msg:      "feat: drop node 12\n\nBREAKING CHANGE: node 12 is unsupported\nbecause it is EOL.",
+			check:    checkBreakingChangeMarker,
+			expected: []string{"Breaking change footer requires a '!' before the separator in the header"},
+			fixed:    "feat!: drop node 12\n\nBREAKING CHANGE: node 12 is unsupported\nbecause it is EOL.",
+		},
+		{
+			name:     "Footer without marker",
+			msg:      "feat(api): drop v1\n\nBREAKING CHANGE: v1 is gone",
+			check:    checkBreakingChangeMarker,
+			expected: []string{"Breaking change footer requires a '!' before the separator in the header"},
+			fixed:    "feat(api)!: drop v1\n\nBREAKING CHANGE: v1 is gone",
+		},
+		{
+			name:  "Footer with marker",
+			msg:   "feat(api)!: drop v1\n\nBREAKING CHANGE: v1 is gone",
+			check: checkBreakingChangeMarker,
+		},
+		{
+			name:  "Breaking change mentioned in the body",
+			msg:   "feat: add v2\n\nNo BREAKING CHANGE: v1 still works.\nIt is deprecated.",
+			check: checkBreakingChangeMarker,
+		},
+		{
+			name:     "Empty description",
+			msg:      "feat!: drop v1\n\nRefs: #12\nBREAKING CHANGE:",
+			check:    checkBreakingChangeEmpty,
+			expected: []string{"BREAKING CHANGE footer must describe the breaking change"},
+		},
+		{
+			name:  "Empty description is not a format error",
+			msg:   "feat!: drop v1\n\nRefs: #12\nBREAKING CHANGE:",
+			check: checkFooterFormat,
+		},
+		{
+			name:  "Either spelling by default",
+			msg:   "feat!: drop v1\n\nBREAKING-CHANGE: v1 is gone\nBREAKING CHANGE: v2 is renamed",
+			check: checkBreakingChangeToken,
+		},
+		{
+			name:     "Lowercase token",
+			msg:      "feat!: drop v1\n\nBreaking-Change: v1 is gone",
+			check:    checkBreakingChangeToken,
+			expected: []string{"Breaking change footer must be spelled 'BREAKING-CHANGE' (found 'Breaking-Change')"},
+			fixed:    "feat!: drop v1\n\nBREAKING-CHANGE: v1 is gone",
+		},
+		{
+			name:     "Configured spelling",
+			msg:      "feat!: drop v1\n\nBREAKING CHANGE: v1 is gone",
+			config:   hyphenated,
+			check:    checkBreakingChangeToken,
+			expected: []string{"Breaking change footer must be spelled 'BREAKING-CHANGE' (found 'BREAKING CHANGE')"},
+			fixed:    "feat!: drop v1\n\nBREAKING-CHANGE: v1 is gone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config.HeaderSeparator == "" {
+				config = defaultConfig
+			}
+			violations := tt.check(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			var fixed string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+				if v.Fix != nil {
+					fixed = v.Fix.Apply(tt.msg)
+				}
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("check() = %v, want %v", messages, tt.expected)
+			}
+			if fixed != tt.fixed {
+				t.Errorf("Fix.Apply() = %q, want %q", fixed, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestValidateBreakingChangeToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "Unset", token: ""},
+		{name: "Space", token: "BREAKING CHANGE"},
+		{name: "Hyphen", token: "BREAKING-CHANGE"},
+		{name: "Lowercase", token: "breaking change", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBreakingChangeToken(Config{BreakingChangeToken: tt.token}); (err != nil) != tt.wantErr {
+				t.Errorf("validateBreakingChangeToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBreakingChangeFooters(t *testing.T) {
+	msg := "feat!: drop node 12\n\nBREAKING CHANGE: node 12 is unsupported\nbecause it is EOL.\n\nRefs: #12\nBREAKING-CHANGE: v1 is gone"
+	expected := []Footer{
+		{Token: "BREAKING CHANGE", Separator: ": ", Value: "node 12 is unsupported\nbecause it is EOL.", Offset: 21, Line: 3},
+		{Token: "BREAKING-CHANGE", Separator: ": ", Value: "v1 is gone", Offset: 91, Line: 7},
+	}
+	if footers := breakingChangeFooters(parseCommit(msg, ": ")); !reflect.DeepEqual(footers, expected) {
+		t.Errorf("breakingChangeFooters() = %+v, want %+v", footers, expected)
+	}
+}
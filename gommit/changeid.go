@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const changeIDToken = "Change-Id"
+
+// emptyTreeHash is the refhash Gerrit's hook uses before the first commit.
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+var (
+	changeIDPattern = regexp.MustCompile(`^I[0-9a-f]{40}$`)
+	// autosquashPattern matches the fixup!, squash! and amend! commits Gerrit's
+	// hook leaves alone, since they are folded into another change.
+	autosquashPattern = regexp.MustCompile(`^[a-z]+! `)
+)
+
+// checkChangeID requires the "Change-Id: I<sha1>" trailer Gerrit uses to
+// track a change across amends and rebases. When it is missing, the fix
+// generates one the way Gerrit's commit-msg hook does, so an existing
+// Change-Id is always preserved. Like the hook, it honours
+// gerrit.createChangeId=false and skips autosquash commits.
+func checkChangeID(commit *ParsedCommit, config Config) []Violation {
+	if autosquashPattern.MatchString(commit.Header) {
+		return nil
+	}
+	if create, err := runGit("config", "gerrit.createChangeId"); err == nil && create == "false" {
+		return nil
+	}
+
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		if !strings.EqualFold(footer.Token, changeIDToken) {
+			continue
+		}
+		if !changeIDPattern.MatchString(footer.Value) {
+			violations = append(violations, Violation{
+				Line: footer.Line, Column: 1, EndColumn: len(commit.Lines[footer.Line-1]) + 1,
+				Message: fmt.Sprintf("%s must be in format: %s: I<40 hexadecimal digits>", changeIDToken, changeIDToken),
+			})
+		}
+		return violations
+	}
+
+	line := len(commit.Lines)
+	v := Violation{
+		Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1,
+		Message: fmt.Sprintf("Commit must have a '%s' footer", changeIDToken),
+	}
+	if id, err := generateChangeID(commit.Raw); err == nil {
+		v.Fix = &Fix{
+			Description: fmt.Sprintf("Insert '%s: %s'", changeIDToken, id),
+			Apply: func(msg string) string {
+				return insertChangeID(msg, id)
+			},
+		}
+	}
+	return []Violation{v}
+}
+
+// generateChangeID computes a Change-Id like Gerrit's commit-msg hook: the
+// object name of a blob holding the committer identity, the HEAD commit (or
+// the empty tree) and the message.
+func generateChangeID(msg string) (string, error) {
+	ident, err := runGit("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", err
+	}
+	refhash, err := runGit("rev-parse", "--verify", "HEAD")
+	if err != nil {
+		refhash = emptyTreeHash
+	}
+
+	content := ident + "\n" + refhash + "\n" + strings.TrimSpace(msg) + "\n"
+	return fmt.Sprintf("I%x", sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content)))), nil
+}
+
+// insertChangeID adds the Change-Id before the first Signed-off-by trailer,
+// where Gerrit's hook puts it, or at the end of the footers.
+func insertChangeID(msg, id string) string {
+	msg = strings.TrimSpace(msg)
+	for _, footer := range parseCommit(msg, "").Footers {
+		if strings.EqualFold(footer.Token, signedOffByToken) {
+			return insertLine(msg, footer.Line, changeIDToken+": "+id)
+		}
+	}
+	return appendFooter(msg, changeIDToken, id)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckChangeID(t *testing.T) {
+	git := map[string]string{
+		"var GIT_COMMITTER_IDENT": "Jane Doe <jane@example.com> 1700000000 +0000",
+		"rev-parse --verify HEAD": "1111111111111111111111111111111111111111",
+	}
+	const id = "Ib8334f5806b11ceaa1cdaea4f959055f2c4891ae"
+
+	tests := []struct {
+		name     string
+		git      map[string]string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name:     "Missing Change-Id",
+			git:      git,
+			msg:      "feat: add parser\n\nRefs: #12",
+			expected: []string{"Commit must have a 'Change-Id' footer"},
+			fixed:    "feat: add parser\n\nRefs: #12\nChange-Id: " + id,
+		},
+		{
+			name:     "Inserted before the sign-off",
+			git:      git,
+			msg:      "feat: add parser\n\nRefs: #12\n\nSigned-off-by: Jane Doe <jane@example.com>\nSigned-off-by: John Doe <john@example.com>",
+			expected: []string{"Commit must have a 'Change-Id' footer"},
+			fixed:    "feat: add parser\n\nRefs: #12\n\nChange-Id: I515c5e77e2a0e19e7f59822d21ff59fd520273c0\nSigned-off-by: Jane Doe <jane@example.com>\nSigned-off-by: John Doe <john@example.com>",
+		},
+		{
+			name: "Existing Change-Id is preserved",
+			git:  git,
+			msg:  "feat: add parser\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Malformed Change-Id",
+			git:      git,
+			msg:      "feat: add parser\n\nChange-Id: 42",
+			expected: []string{"Change-Id must be in format: Change-Id: I<40 hexadecimal digits>"},
+		},
+		{
+			name: "Autosquash commit",
+			git:  git,
+			msg:  "fixup! feat: add parser",
+		},
+		{
+			name: "Disabled in git config",
+			git: map[string]string{
+				"config gerrit.createChangeId": "false",
+			},
+			msg: "feat: add parser",
+		},
+		{
+			name:     "Unknown committer identity",
+			git:      map[string]string{},
+			msg:      "feat: add parser",
+			expected: []string{"Commit must have a 'Change-Id' footer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubGit(t, tt.git)
+			violations := checkChangeID(parseCommit(tt.msg, ": "), defaultConfig)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkChangeID() = %v, want %v", messages, tt.expected)
+			}
+			var fixed string
+			if len(violations) > 0 && violations[0].Fix != nil {
+				fixed = violations[0].Fix.Apply(tt.msg)
+			}
+			if fixed != tt.fixed {
+				t.Errorf("Fix.Apply() = %q, want %q", fixed, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestGenerateChangeID(t *testing.T) {
+	stubGit(t, map[string]string{
+		"var GIT_COMMITTER_IDENT": "Jane Doe <jane@example.com> 1700000000 +0000",
+	})
+	// Before the first commit, the empty tree stands in for HEAD.
+	id, err := generateChangeID("feat: add parser")
+	if err != nil {
+		t.Fatalf("generateChangeID() error = %v", err)
+	}
+	if expected := "Idea76e4cb1a0d3efaf215840d88a4371e4b79b47"; id != expected {
+		t.Errorf("generateChangeID() = %q, want %q", id, expected)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	cleanupDefault    = "default"
+	cleanupStrip      = "strip"
+	cleanupWhitespace = "whitespace"
+	cleanupVerbatim   = "verbatim"
+	cleanupScissors   = "scissors"
+)
+
+// scissorsLine follows the comment string on the line added by
+// "git commit -v"; git drops it and everything below it.
+const scissorsLine = "------------------------ >8 ------------------------"
+
+// autoCommentChars are the characters git picks from, in order, when
+// core.commentChar is "auto".
+const autoCommentChars = "#;@!$%^&|:"
+
+// Cleanup is git's commit.cleanup mode together with the comment string,
+// read from the git configuration.
+type Cleanup struct {
+	Mode    string
+	Comment string
+}
+
+// gitCleanup reads commit.cleanup and core.commentString (or
+// core.commentChar), falling back to git's defaults when they are unset or
+// git is not available. msg is used to resolve an "auto" comment character.
+func gitCleanup(msg string) Cleanup {
+	cleanup := Cleanup{Mode: cleanupDefault, Comment: "#"}
+	if mode, err := runGit("config", "commit.cleanup"); err == nil && mode != "" {
+		cleanup.Mode = mode
+	}
+	comment, err := runGit("config", "core.commentString")
+	if err != nil || comment == "" {
+		comment, err = runGit("config", "core.commentChar")
+	}
+	if err == nil && comment != "" {
+		cleanup.Comment = comment
+	}
+	if cleanup.Comment == "auto" {
+		cleanup.Comment = autoCommentChar(msg)
+	}
+	return cleanup
+}
+
+// autoCommentChar guesses the comment character git picked in "auto" mode.
+// Git chooses a character that no line of the message starts with and then
+// appends its instructions, so the last comment line tells which one it is.
+func autoCommentChar(msg string) string {
+	lines := strings.Split(strings.TrimRightFunc(msg, unicode.IsSpace), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := lines[i]; line != "" {
+			if strings.ContainsRune(autoCommentChars, rune(line[0])) {
+				return line[:1]
+			}
+			break
+		}
+	}
+	return "#"
+}
+
+// Apply cleans msg up the way git does before recording the commit. The
+// commit-msg hook runs before git's own cleanup, so the message it receives
+// still holds the instructions, the "git commit -v" diff and stray
+// whitespace. The result is left unchanged by git, whatever the mode.
+func (c Cleanup) Apply(msg string) string {
+	edited := wasEdited(msg, c.Comment)
+	msg = cutAtScissors(msg, c.Comment)
+	switch c.Mode {
+	case cleanupVerbatim:
+		return msg
+	case cleanupWhitespace, cleanupScissors:
+		return stripSpace(msg, "")
+	case cleanupStrip:
+		return stripSpace(msg, c.Comment)
+	default:
+		// "default" strips comments only when the message was edited, and
+		// behaves like "whitespace" for "git commit -m" and "-F".
+		if edited {
+			return stripSpace(msg, c.Comment)
+		}
+		return stripSpace(msg, "")
+	}
+}
+
+// wasEdited reports whether msg went through the editor, which git tells by
+// appending its instructions: a comment block holding an empty comment line,
+// or the scissors line of "git commit -v".
+func wasEdited(msg, comment string) bool {
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == comment || line == comment+" "+scissorsLine || strings.HasPrefix(line, comment+" Please enter ") {
+			return true
+		}
+	}
+	return false
+}
+
+func cutAtScissors(msg, comment string) string {
+	cut := comment + " " + scissorsLine
+	lines := strings.SplitAfter(msg, "\n")
+	for i, line := range lines {
+		if strings.TrimSuffix(line, "\n") == cut {
+			return strings.Join(lines[:i], "")
+		}
+	}
+	return msg
+}
+
+// stripSpace mirrors git stripspace: it removes trailing whitespace, leading
+// and trailing blank lines, collapses consecutive blank lines and, when
+// comment is not empty, drops the lines starting with it.
+func stripSpace(msg, comment string) string {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(msg, "\n") {
+		if comment != "" && strings.HasPrefix(line, comment) {
+			continue
+		}
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
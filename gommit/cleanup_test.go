@@ -0,0 +1,136 @@
+package main
+
+import "testing"
+
+func TestCleanupApply(t *testing.T) {
+	const template = "feat: add parser  \n\n\n\nBuild an AST.\n# Please enter the commit message for your changes.\n#\n"
+	const verbose = "feat: add parser\n\n# Please enter the commit message for your changes.\n" +
+		"# ------------------------ >8 ------------------------\n" +
+		"# Do not modify or remove the line above.\ndiff --git a/parser.go b/parser.go\n+# not a comment\n"
+
+	tests := []struct {
+		name     string
+		cleanup  Cleanup
+		msg      string
+		expected string
+	}{
+		{
+			name:     "Default strips comments and whitespace",
+			cleanup:  Cleanup{Mode: cleanupDefault, Comment: "#"},
+			msg:      template,
+			expected: "feat: add parser\n\nBuild an AST.\n",
+		},
+		{
+			name:     "Whitespace keeps comments",
+			cleanup:  Cleanup{Mode: cleanupWhitespace, Comment: "#"},
+			msg:      template,
+			expected: "feat: add parser\n\nBuild an AST.\n# Please enter the commit message for your changes.\n#\n",
+		},
+		{
+			name:     "Verbatim keeps everything",
+			cleanup:  Cleanup{Mode: cleanupVerbatim, Comment: "#"},
+			msg:      template,
+			expected: template,
+		},
+		{
+			name:     "Scissors line drops the diff",
+			cleanup:  Cleanup{Mode: cleanupStrip, Comment: "#"},
+			msg:      verbose,
+			expected: "feat: add parser\n",
+		},
+		{
+			name:     "Scissors mode keeps comments above the line",
+			cleanup:  Cleanup{Mode: cleanupScissors, Comment: "#"},
+			msg:      verbose,
+			expected: "feat: add parser\n\n# Please enter the commit message for your changes.\n",
+		},
+		{
+			name:     "Custom comment char",
+			cleanup:  Cleanup{Mode: cleanupStrip, Comment: ";"},
+			msg:      "fix: handle #12\n\n#12 was caused by a nil map.\n; Please enter the commit message.\n",
+			expected: "fix: handle #12\n\n#12 was caused by a nil map.\n",
+		},
+		{
+			name:     "Default keeps comment lines of a message given with -m",
+			cleanup:  Cleanup{Mode: cleanupDefault, Comment: "#"},
+			msg:      "fix: x\n\n#123 is the issue\n",
+			expected: "fix: x\n\n#123 is the issue\n",
+		},
+		{
+			name:     "Default strips comments above the scissors line",
+			cleanup:  Cleanup{Mode: cleanupDefault, Comment: "#"},
+			msg:      verbose,
+			expected: "feat: add parser\n",
+		},
+		{
+			name:     "Strip drops comment lines of a message given with -m",
+			cleanup:  Cleanup{Mode: cleanupStrip, Comment: "#"},
+			msg:      "fix: x\n\n#123 is the issue\n",
+			expected: "fix: x\n",
+		},
+		{
+			name:     "Only comments",
+			cleanup:  Cleanup{Mode: cleanupStrip, Comment: "#"},
+			msg:      "\n# Please enter the commit message for your changes.\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.cleanup.Apply(tt.msg)
+			if result != tt.expected {
+				t.Errorf("Apply() = %q, want %q", result, tt.expected)
+			}
+			if again := tt.cleanup.Apply(result); again != result {
+				t.Errorf("Apply() is not idempotent: %q became %q", result, again)
+			}
+		})
+	}
+}
+
+func TestGitCleanup(t *testing.T) {
+	tests := []struct {
+		name     string
+		outputs  map[string]string
+		msg      string
+		expected Cleanup
+	}{
+		{
+			name:     "Defaults",
+			outputs:  map[string]string{},
+			expected: Cleanup{Mode: cleanupDefault, Comment: "#"},
+		},
+		{
+			name: "Configured mode and comment char",
+			outputs: map[string]string{
+				"config commit.cleanup":   "scissors",
+				"config core.commentChar": ";",
+			},
+			expected: Cleanup{Mode: cleanupScissors, Comment: ";"},
+		},
+		{
+			name: "Comment string takes precedence",
+			outputs: map[string]string{
+				"config core.commentString": "//",
+				"config core.commentChar":   ";",
+			},
+			expected: Cleanup{Mode: cleanupDefault, Comment: "//"},
+		},
+		{
+			name:     "Auto comment char",
+			outputs:  map[string]string{"config core.commentChar": "auto"},
+			msg:      "fix: handle #12\n\n#12 was caused by a nil map.\n; Please enter the commit message.\n;\n",
+			expected: Cleanup{Mode: cleanupDefault, Comment: ";"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubGit(t, tt.outputs)
+			if result := gitCleanup(tt.msg); result != tt.expected {
+				t.Errorf("gitCleanup() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	targetHeader      = "header"
+	targetDescription = "description"
+	targetScope       = "scope"
+	targetBody        = "body"
+	targetFooter      = "footer"
+	targetMessage     = "message"
+)
+
+var customRuleTargets = []string{targetHeader, targetDescription, targetScope, targetBody, targetFooter, targetMessage}
+
+// CustomRule is a regex rule declared in the custom_rules section of the
+// configuration. Exactly one of MustMatch and MustNotMatch is set.
+type CustomRule struct {
+	Name         string   `yaml:"name"`
+	Target       string   `yaml:"target"`
+	MustMatch    string   `yaml:"must_match"`
+	MustNotMatch string   `yaml:"must_not_match"`
+	Severity     Severity `yaml:"severity"`
+	Message      string   `yaml:"message"`
+}
+
+// customRule adapts a CustomRule to the Rule interface.
+type customRule struct {
+	CustomRule
+}
+
+func (r customRule) Name() string { return r.CustomRule.Name }
+
+func (r customRule) Description() string {
+	if r.MustMatch != "" {
+		return fmt.Sprintf("%s must match %s", capitalize(r.Target), r.MustMatch)
+	}
+	return fmt.Sprintf("%s must not match %s", capitalize(r.Target), r.MustNotMatch)
+}
+
+func (r customRule) DefaultSeverity() Severity {
+	if r.Severity == "" {
+		return SeverityError
+	}
+	return r.Severity
+}
+
+func (r customRule) Check(commit *ParsedCommit, config Config) []Violation {
+	text, offset := customRuleTarget(commit, r.Target)
+	message := r.Message
+	if message == "" {
+		message = r.Description()
+	}
+
+	if r.MustMatch != "" {
+		pattern, err := regexp.Compile(r.MustMatch)
+		if err != nil {
+			return []Violation{{Line: 1, Column: 1, EndColumn: 1, Message: err.Error()}}
+		}
+		if pattern.MatchString(text) {
+			return nil
+		}
+		line, column := commit.position(offset)
+		return []Violation{{Line: line, Column: column, EndColumn: column + len(firstLine(text)), Message: message}}
+	}
+
+	pattern, err := regexp.Compile(r.MustNotMatch)
+	if err != nil {
+		return []Violation{{Line: 1, Column: 1, EndColumn: 1, Message: err.Error()}}
+	}
+	loc := pattern.FindStringIndex(text)
+	if loc == nil {
+		return nil
+	}
+	line, column := commit.position(offset + loc[0])
+	return []Violation{{Line: line, Column: column, EndColumn: column + len(firstLine(text[loc[0]:loc[1]])), Message: message}}
+}
+
+func (r CustomRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("custom rule without a name")
+	}
+	if lookupRule(r.Name) != nil {
+		return fmt.Errorf("custom rule %q conflicts with a built-in rule", r.Name)
+	}
+	if !contains(customRuleTargets, r.Target) {
+		return fmt.Errorf("custom rule %q: invalid target %q (expected one of %s)", r.Name, r.Target, strings.Join(customRuleTargets, ", "))
+	}
+	if (r.MustMatch == "") == (r.MustNotMatch == "") {
+		return fmt.Errorf("custom rule %q: exactly one of must_match and must_not_match is required", r.Name)
+	}
+	if _, err := regexp.Compile(r.MustMatch + r.MustNotMatch); err != nil {
+		return fmt.Errorf("custom rule %q: %w", r.Name, err)
+	}
+	if r.Severity != "" && !r.Severity.valid() {
+		return fmt.Errorf("custom rule %q: invalid severity %q (expected error, warning or off)", r.Name, r.Severity)
+	}
+	return nil
+}
+
+func validateCustomRules(config Config) error {
+	seen := map[string]bool{}
+	for _, rule := range config.CustomRules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("custom rule %q is declared twice", rule.Name)
+		}
+		seen[rule.Name] = true
+	}
+	return nil
+}
+
+func lookupCustomRule(config Config, name string) Rule {
+	for _, rule := range config.CustomRules {
+		if rule.Name == name {
+			return customRule{rule}
+		}
+	}
+	return nil
+}
+
+// activeRules returns the registered rules followed by the custom rules of
+// the configuration.
+func activeRules(config Config) []Rule {
+	rules := append([]Rule(nil), registeredRules()...)
+	for _, rule := range config.CustomRules {
+		rules = append(rules, customRule{rule})
+	}
+	return rules
+}
+
+// customRuleTarget returns the part of the commit a custom rule applies to and
+// its byte offset in the raw message.
+func customRuleTarget(commit *ParsedCommit, target string) (string, int) {
+	switch target {
+	case targetHeader:
+		return commit.Header, 0
+	case targetDescription:
+		return commit.Description, commit.DescriptionOffset
+	case targetScope:
+		return commit.Scope, commit.ScopeOffset
+	case targetBody:
+		return commit.Body, commit.lineOffset(commit.BodyStart)
+	case targetFooter:
+		return strings.Join(commit.FooterLines(), "\n"), commit.lineOffset(commit.FooterStart)
+	default:
+		return commit.Raw, 0
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
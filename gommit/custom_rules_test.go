@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCustomRuleCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   CustomRule
+		msg    string
+		line   int
+		column int
+		fails  bool
+	}{
+		{
+			name:   "Forbidden word in description",
+			rule:   CustomRule{Name: "no-wip", Target: targetDescription, MustNotMatch: `(?i)\bwip\b`},
+			msg:    "feat: wip parser",
+			line:   1,
+			column: 7,
+			fails:  true,
+		},
+		{
+			name: "Forbidden word absent",
+			rule: CustomRule{Name: "no-wip", Target: targetDescription, MustNotMatch: `(?i)\bwip\b`},
+			msg:  "feat: add parser",
+		},
+		{
+			name: "Ticket id in footer",
+			rule: CustomRule{Name: "ticket", Target: targetFooter, MustMatch: `(?m)^Refs: PROJ-\d+$`},
+			msg:  "feat: add parser\n\nRefs: PROJ-12",
+		},
+		{
+			name:   "Missing ticket id",
+			rule:   CustomRule{Name: "ticket", Target: targetFooter, MustMatch: `(?m)^Refs: PROJ-\d+$`},
+			msg:    "feat: add parser\n\nSome body.",
+			line:   1,
+			column: 1,
+			fails:  true,
+		},
+		{
+			name:   "Match in body",
+			rule:   CustomRule{Name: "no-todo", Target: targetBody, MustNotMatch: `TODO`},
+			msg:    "feat: add parser\n\nFirst line.\nStill a TODO here.",
+			line:   4,
+			column: 9,
+			fails:  true,
+		},
+		{
+			name: "Scope pattern",
+			rule: CustomRule{Name: "scope-prefix", Target: targetScope, MustMatch: `^(pkg|cmd)-`},
+			msg:  "feat(pkg-parser): add ast",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := customRule{tt.rule}.Check(parseCommit(tt.msg, ": "), defaultConfig)
+			if (len(violations) > 0) != tt.fails {
+				t.Fatalf("Check() = %v, want failure %v", violations, tt.fails)
+			}
+			if tt.fails && (violations[0].Line != tt.line || violations[0].Column != tt.column) {
+				t.Errorf("Check() position = %d:%d, want %d:%d", violations[0].Line, violations[0].Column, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMsgCustomRules(t *testing.T) {
+	config := defaultConfig
+	config.CustomRules = []CustomRule{
+		{Name: "no-wip", Target: targetMessage, MustNotMatch: `(?i)\bwip\b`, Severity: SeverityWarning, Message: "Do not commit work in progress"},
+	}
+
+	violations := validateCommitMsg("feat: wip parser", config)
+	if len(violations) != 1 || violations[0].Rule != "no-wip" || violations[0].Severity != SeverityWarning || violations[0].Message != "Do not commit work in progress" {
+		t.Errorf("validateCommitMsg() = %v, want a single no-wip warning", violations)
+	}
+
+	config.Rules = map[string]Severity{"no-wip": SeverityError}
+	if violations := validateCommitMsg("feat: wip parser", config); !hasErrors(violations) {
+		t.Errorf("validateCommitMsg() = %v, want no-wip raised to error", violations)
+	}
+
+	config.DisabledRules = []string{"no-wip"}
+	if violations := validateCommitMsg("feat: wip parser", config); len(violations) != 0 {
+		t.Errorf("validateCommitMsg() = %v, want no-wip disabled", violations)
+	}
+}
+
+func TestValidateCustomRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []CustomRule
+		wantErr bool
+	}{
+		{
+			name:  "Valid rule",
+			rules: []CustomRule{{Name: "no-wip", Target: targetHeader, MustNotMatch: "WIP"}},
+		},
+		{
+			name:    "Missing name",
+			rules:   []CustomRule{{Target: targetHeader, MustNotMatch: "WIP"}},
+			wantErr: true,
+		},
+		{
+			name:    "Built-in name",
+			rules:   []CustomRule{{Name: "header-format", Target: targetHeader, MustNotMatch: "WIP"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown target",
+			rules:   []CustomRule{{Name: "no-wip", Target: "subject", MustNotMatch: "WIP"}},
+			wantErr: true,
+		},
+		{
+			name:    "Both patterns",
+			rules:   []CustomRule{{Name: "no-wip", Target: targetHeader, MustMatch: "a", MustNotMatch: "b"}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid pattern",
+			rules:   []CustomRule{{Name: "no-wip", Target: targetHeader, MustNotMatch: "("}},
+			wantErr: true,
+		},
+		{
+			name: "Duplicate names",
+			rules: []CustomRule{
+				{Name: "no-wip", Target: targetHeader, MustNotMatch: "WIP"},
+				{Name: "no-wip", Target: targetBody, MustNotMatch: "WIP"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomRules(Config{CustomRules: tt.rules})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCustomRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigCustomRules(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", TEST_DIR)
+	if err != nil {
+		t.Fatalf(TEMP_DIR_CREATION_FAILURE, err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	configPath := filepath.Join(tempDir, "gommit.conf.yaml")
+	content := []byte(`
+custom_rules:
+  - name: ticket-id
+    target: footer
+    must_match: '(?m)^Refs: PROJ-\d+$'
+    severity: warning
+    message: Reference a PROJ ticket
+rules:
+  ticket-id: error
+`)
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("Failed to write mock config file: %v", err)
+	}
+
+	config, err := loadConfig(MockConfigPathGetter{ConfigPath: configPath})
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if len(config.CustomRules) != 1 || config.CustomRules[0].Message != "Reference a PROJ ticket" {
+		t.Errorf("loadConfig() custom rules = %+v", config.CustomRules)
+	}
+	if severity := ruleSeverity(config, "ticket-id"); severity != SeverityError {
+		t.Errorf("ruleSeverity() = %v, want %v", severity, SeverityError)
+	}
+}
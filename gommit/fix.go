@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// maxFixPasses bounds applyFixes in case two fixes keep undoing each other.
+const maxFixPasses = 50
+
+// applyFixes applies the fixes offered by the violations of msg one at a time
+// and re-validates after each, since a fix moves the positions the others
+// were computed against. Fixes are applied in rule order, then in message
+// order, so the result does not depend on the order violations were
+// reported in. It returns the fixed message and the violations that were
+// fixed. When rule names are given, only the fixes of those rules are applied.
+// A fix that leaves the message unchanged, such as rewrapping a line holding a
+// word longer than the limit, is skipped and the next one is tried.
+func applyFixes(msg string, config Config, ruleNames ...string) (string, []Violation) {
+	msg = strings.TrimSpace(msg)
+	var applied []Violation
+	skipped := map[fixKey]bool{}
+	for pass := 0; pass < maxFixPasses; pass++ {
+		v, ok := firstFixable(validateCommitMsg(msg, config), ruleNames, skipped)
+		if !ok {
+			break
+		}
+		fixed := strings.TrimSpace(v.Fix.Apply(msg))
+		if fixed == msg {
+			skipped[fixKey{v.Rule, v.Line, v.Column}] = true
+			continue
+		}
+		msg = fixed
+		applied = append(applied, v)
+	}
+	return msg, applied
+}
+
+// fixKey identifies a violation across re-validations of the same message.
+type fixKey struct {
+	rule   string
+	line   int
+	column int
+}
+
+func firstFixable(violations []Violation, ruleNames []string, skipped map[fixKey]bool) (Violation, bool) {
+	for _, v := range violations {
+		if v.Fix == nil || skipped[fixKey{v.Rule, v.Line, v.Column}] {
+			continue
+		}
+		if len(ruleNames) == 0 || contains(ruleNames, v.Rule) {
+			return v, true
+		}
+	}
+	return Violation{}, false
+}
+
+// runCheck implements "gommit check [--fix] <file>": it validates the commit
+// message in file without prompting, optionally fixing it in place first.
+func runCheck(pathGetter ConfigPathGetter, args []string) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	fix := flags.Bool("fix", false, "apply the suggested fixes and write the message back")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: gommit check [--fix] <file>")
+	}
+	commitMsgFile := flags.Arg(0)
+
+	config, err := loadConfig(pathGetter)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if config.TrailerSeparators == "" {
+		config.TrailerSeparators = gitTrailerSeparators()
+	}
+
+	commitMsg, err := readCommitMsg(commitMsgFile)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message file: %w", err)
+	}
+	cleanup := gitCleanup(commitMsg)
+	commitMsg = cleanup.Apply(commitMsg)
+
+	if reason, ok := ignoreReason(commitMsg, config); ok {
+		fmt.Println(headerStyle.Render(fmt.Sprintf("Skipping validation: %s.", reason)))
+		return nil
+	}
+
+	if *fix {
+		fixed, applied := applyFixes(commitMsg, config)
+		if len(applied) > 0 {
+			if err := writeCommitMsg(commitMsgFile, cleanup.Apply(fixed)); err != nil {
+				return fmt.Errorf("failed to write commit message: %w", err)
+			}
+			fmt.Println(successStyle.Render(fmt.Sprintf("✔ Applied %d fix(es):", len(applied))))
+			for _, v := range applied {
+				fmt.Println(detailStyle.Render(fmt.Sprintf("  • %s [%s]", v.Fix.Description, v.Rule)))
+			}
+		}
+		commitMsg = fixed
+	}
+
+	violations := validateCommitMsg(commitMsg, config)
+	for _, v := range violations {
+		fmt.Println(violationStyle(v).Render(formatViolation(v)))
+	}
+	if hasErrors(violations) {
+		return fmt.Errorf("commit message validation failed")
+	}
+	fmt.Println(successStyle.Render("✔ Commit message is valid."))
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+		rules    []string
+	}{
+		{
+			name:     "Valid message",
+			msg:      "feat: add parser",
+			expected: "feat: add parser",
+		},
+		{
+			name:     "Several fixes",
+			msg:      "FEAT(API): Add parser.\nBuild an AST.",
+			expected: "feat(api): add parser\n\nBuild an AST.",
+			rules:    []string{"subject-case", "subject-full-stop", "body-leading-blank", "type-case", "scope-case"},
+		},
+		{
+			name:     "Violations without a fix are left alone",
+			msg:      "feat: this header is way too long and exceeds the maximum length.",
+			expected: "feat: this header is way too long and exceeds the maximum length",
+			rules:    []string{"subject-full-stop"},
+		},
+		{
+			name:     "Fixes that change nothing are skipped",
+			msg:      "Feat(Api): add x\n\nsee " + strings.Repeat("x", 90) + " here",
+			expected: "feat(api): add x\n\nsee\n" + strings.Repeat("x", 90) + "\nhere",
+			rules:    []string{"body-line-max-length", "type-enum", "scope-case"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, applied := applyFixes(tt.msg, defaultConfig)
+			if fixed != tt.expected {
+				t.Errorf("applyFixes() = %q, want %q", fixed, tt.expected)
+			}
+			var rules []string
+			for _, v := range applied {
+				rules = append(rules, v.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Errorf("applyFixes() applied %v, want %v", rules, tt.rules)
+			}
+		})
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	stubGit(t, map[string]string{})
+	tempDir := t.TempDir()
+	pathGetter := MockConfigPathGetter{ConfigPath: filepath.Join(tempDir, "gommit.conf.yaml")}
+	if err := os.WriteFile(pathGetter.ConfigPath, []byte("header_max_length: 50\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	msgFile := filepath.Join(tempDir, "COMMIT_EDITMSG")
+	if err := os.WriteFile(msgFile, []byte("Feat: Add parser.\n# Please enter the commit message.\n"), 0644); err != nil {
+		t.Fatalf("Failed to write commit message file: %v", err)
+	}
+
+	if err := runCheck(pathGetter, []string{msgFile}); err == nil {
+		t.Error("runCheck() without --fix accepted an invalid message")
+	}
+
+	if err := runCheck(pathGetter, []string{"--fix", msgFile}); err != nil {
+		t.Fatalf("runCheck() --fix error = %v", err)
+	}
+	content, err := os.ReadFile(msgFile)
+	if err != nil {
+		t.Fatalf("Failed to read commit message file: %v", err)
+	}
+	if expected := "feat: add parser\n"; string(content) != expected {
+		t.Errorf("runCheck() --fix wrote %q, want %q", content, expected)
+	}
+
+	if err := runCheck(pathGetter, nil); err == nil {
+		t.Error("runCheck() without a file did not fail")
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FooterConfig declares a footer token. Pattern must match the whole value.
+// Tokens are matched case-insensitively, like git trailers.
+type FooterConfig struct {
+	Token    string `yaml:"token"`
+	Pattern  string `yaml:"pattern"`
+	Required bool   `yaml:"required"`
+	Unique   bool   `yaml:"unique"`
+}
+
+// FootersConfig is the footers section of the configuration.
+type FootersConfig struct {
+	RejectUnknown bool           `yaml:"reject_unknown"`
+	Tokens        []FooterConfig `yaml:"tokens"`
+}
+
+func (c FootersConfig) lookup(token string) (FooterConfig, bool) {
+	for _, footer := range c.Tokens {
+		if strings.EqualFold(footer.Token, token) {
+			return footer, true
+		}
+	}
+	return FooterConfig{}, false
+}
+
+func isBreakingChangeToken(token string) bool {
+	return token == "BREAKING CHANGE" || token == "BREAKING-CHANGE"
+}
+
+// knownFooterTokens are the footer tokens checked for misspellings, along
+// with the configured reference token and the declared footers.
+var knownFooterTokens = []string{
+	"BREAKING CHANGE", "BREAKING-CHANGE",
+	"Signed-off-by", "Reviewed-by", "Acked-by", "Tested-by", "Reported-by", "Co-authored-by",
+	"Co-developed-by", "Helped-by", "Suggested-by", "Requested-by", "Mentored-by", "Cc", "Link",
+	"Change-Id", "Reviewed-on", "Bug",
+	"Refs", "Closes", "Fixes",
+}
+
+// closestFooterToken is closestMatch with a single edit allowed for
+// hyphenated tokens: trailers such as "Helped-by" and "Tested-by" differ by
+// a couple of letters, so a larger distance would turn real trailers into
+// typos.
+func closestFooterToken(token string, candidates []string) (string, bool) {
+	if !strings.Contains(token, "-") {
+		return closestMatch(token, candidates)
+	}
+	if match, ok := editDistanceMatchWithin(token, candidates, 1); ok {
+		return match, true
+	}
+	return prefixMatch(token, candidates)
+}
+
+// checkFooterToken suggests the well-known token a footer token is a likely
+// misspelling of, such as "Signed-of-by". Tokens are compared case-insensitively,
+// like git trailers.
+func checkFooterToken(commit *ParsedCommit, config Config) []Violation {
+	var known, lowered []string
+	add := func(token string) {
+		if lower := strings.ToLower(token); token != "" && !contains(lowered, lower) {
+			known = append(known, token)
+			lowered = append(lowered, lower)
+		}
+	}
+	for _, token := range knownFooterTokens {
+		add(token)
+	}
+	add(config.ReferenceToken)
+	for _, footer := range config.Footers.Tokens {
+		add(footer.Token)
+	}
+
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		token := strings.ToLower(footer.Token)
+		if contains(lowered, token) {
+			continue
+		}
+		match, ok := closestFooterToken(token, lowered)
+		if !ok {
+			continue
+		}
+		suggestion := known[indexOf(lowered, match)]
+		line := commit.Lines[footer.Line-1]
+		fixed := suggestion + line[len(footer.Token):]
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: 1, EndColumn: len(footer.Token) + 1,
+			Message: fmt.Sprintf("Footer token '%s' is not a known token — did you mean '%s'?", footer.Token, suggestion),
+			Fix: &Fix{
+				Description: fmt.Sprintf("Change footer token '%s' to '%s'", footer.Token, suggestion),
+				Apply: func(msg string) string {
+					return replaceLine(msg, footer.Line, fixed)
+				},
+			},
+		})
+	}
+	return violations
+}
+
+func indexOf(slice []string, item string) int {
+	for i, s := range slice {
+		if s == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkFooterEnum rejects the tokens not declared in footers.tokens when
+// footers.reject_unknown is set. BREAKING CHANGE is always accepted.
+func checkFooterEnum(commit *ParsedCommit, config Config) []Violation {
+	if !config.Footers.RejectUnknown {
+		return nil
+	}
+	var declared []string
+	for _, footer := range config.Footers.Tokens {
+		declared = append(declared, footer.Token)
+	}
+
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		if _, ok := config.Footers.lookup(footer.Token); ok || isBreakingChangeToken(footer.Token) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: 1, EndColumn: len(footer.Token) + 1,
+			Message: fmt.Sprintf("Footer token '%s' is not allowed. Allowed tokens are: %s", footer.Token, strings.Join(declared, ", ")),
+		})
+	}
+	return violations
+}
+
+func checkFooterValue(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		declared, ok := config.Footers.lookup(footer.Token)
+		if !ok || declared.Pattern == "" {
+			continue
+		}
+		// The '#' of "<token> #<value>" belongs to the value, as in "Refs #12".
+		value := strings.TrimSpace(footer.Value)
+		start := len(footer.Token) + len(footer.Separator) + 1
+		if strings.HasSuffix(footer.Separator, "#") {
+			value = "#" + value
+			start--
+		}
+		pattern, err := footerValuePattern(declared.Pattern)
+		if err != nil || pattern.MatchString(value) {
+			continue
+		}
+		line := commit.Lines[footer.Line-1]
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: start, EndColumn: len(line) + 1,
+			Message: fmt.Sprintf("Footer '%s' value must match %s", footer.Token, declared.Pattern),
+		})
+	}
+	return violations
+}
+
+func checkFooterRequired(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	for _, declared := range config.Footers.Tokens {
+		if !declared.Required || footerCount(commit, declared.Token) > 0 {
+			continue
+		}
+		line := len(commit.Lines)
+		violations = append(violations, Violation{
+			Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1,
+			Message: fmt.Sprintf("Footer '%s' is required", declared.Token),
+		})
+	}
+	return violations
+}
+
+func checkFooterUnique(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	seen := map[string]bool{}
+	for _, footer := range commit.Footers {
+		declared, ok := config.Footers.lookup(footer.Token)
+		if !ok || !declared.Unique {
+			continue
+		}
+		key := strings.ToLower(declared.Token)
+		if seen[key] {
+			violations = append(violations, Violation{
+				Line: footer.Line, Column: 1, EndColumn: len(footer.Token) + 1,
+				Message: fmt.Sprintf("Footer '%s' must not be repeated", declared.Token),
+			})
+		}
+		seen[key] = true
+	}
+	return violations
+}
+
+func footerCount(commit *ParsedCommit, token string) int {
+	count := 0
+	for _, footer := range commit.Footers {
+		if strings.EqualFold(footer.Token, token) {
+			count++
+		}
+	}
+	return count
+}
+
+func footerValuePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+func validateFooters(footers FootersConfig) error {
+	seen := map[string]bool{}
+	for _, footer := range footers.Tokens {
+		if !footerStartPattern.MatchString(footer.Token + ": ") {
+			return fmt.Errorf("invalid footer token %q", footer.Token)
+		}
+		key := strings.ToLower(footer.Token)
+		if seen[key] {
+			return fmt.Errorf("footer token %q is declared twice", footer.Token)
+		}
+		seen[key] = true
+		if _, err := footerValuePattern(footer.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern for footer token %q: %w", footer.Token, err)
+		}
+	}
+	return nil
+}
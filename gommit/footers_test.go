@@ -0,0 +1,228 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckFooterToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "Known tokens",
+			msg:  "feat: add parser\n\nRefs: #12\nSigned-off-by: Jane Doe <jane@example.com>\nco-authored-by: John Doe <john@example.com>",
+		},
+		{
+			name:     "Misspelled sign-off",
+			msg:      "feat: add parser\n\nRefs: #12\nSigned-of-by: Jane Doe <jane@example.com>",
+			expected: []string{"Footer token 'Signed-of-by' is not a known token — did you mean 'Signed-off-by'?"},
+			fixed:    "feat: add parser\n\nRefs: #12\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Misspelled reference",
+			msg:      "fix: handle nil\n\nRef: #12",
+			expected: []string{"Footer token 'Ref' is not a known token — did you mean 'Refs'?"},
+			fixed:    "fix: handle nil\n\nRefs: #12",
+		},
+		{
+			name:     "Misspelled review",
+			msg:      "fix: handle nil\n\nReviewd-by: Z",
+			expected: []string{"Footer token 'Reviewd-by' is not a known token — did you mean 'Reviewed-by'?"},
+			fixed:    "fix: handle nil\n\nReviewed-by: Z",
+		},
+		{
+			name: "Unrelated custom token",
+			msg:  "fix: handle nil\n\nDeployment: staging",
+		},
+		{
+			name: "Git and Gerrit trailers",
+			msg:  "fix: handle nil\n\nHelped-by: Z\nSuggested-by: Y\nReviewed-on: https://review.example.com/c/42\nChange-Id: I0123456789abcdef0123456789abcdef01234567",
+		},
+		{
+			name: "Hyphenated token two edits away",
+			msg:  "fix: handle nil\n\nLiked-by: Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkFooterToken(parseCommit(tt.msg, ": "), defaultConfig)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkFooterToken() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckDeclaredFooters(t *testing.T) {
+	config := defaultConfig
+	config.Footers = FootersConfig{
+		RejectUnknown: true,
+		Tokens: []FooterConfig{
+			{Token: "Reviewed-by", Pattern: `[^<>]+ <[^<>\s]+@[^<>\s]+>`},
+			{Token: "Refs", Pattern: `#\d+|PROJ-\d+`, Required: true, Unique: true},
+			{Token: "Co-authored-by"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		msg      string
+		check    checkFunc
+		expected []string
+	}{
+		{
+			name:  "Declared footers",
+			msg:   "feat: add parser\n\nReviewed-by: Jane Doe <jane@example.com>\nco-authored-by: John Doe <john@example.com>\nRefs: PROJ-12",
+			check: checkFooterEnum,
+		},
+		{
+			name:     "Unknown token",
+			msg:      "feat: add parser\n\nDeployment: staging\nRefs: #12",
+			check:    checkFooterEnum,
+			expected: []string{"Footer token 'Deployment' is not allowed. Allowed tokens are: Reviewed-by, Refs, Co-authored-by"},
+		},
+		{
+			name:  "Breaking change is always allowed",
+			msg:   "feat!: drop v1\n\nBREAKING CHANGE: v1 is gone\nRefs: #12",
+			check: checkFooterEnum,
+		},
+		{
+			name:     "Value not matching the pattern",
+			msg:      "feat: add parser\n\nReviewed-by: jane\nRefs: 12",
+			check:    checkFooterValue,
+			expected: []string{`Footer 'Reviewed-by' value must match [^<>]+ <[^<>\s]+@[^<>\s]+>`, `Footer 'Refs' value must match #\d+|PROJ-\d+`},
+		},
+		{
+			name:  "Pattern matches the whole value",
+			msg:   "feat: add parser\n\nRefs: PROJ-12",
+			check: checkFooterValue,
+		},
+		{
+			name:  "Hash separator belongs to the value",
+			msg:   "feat: add parser\n\nRefs #12",
+			check: checkFooterValue,
+		},
+		{
+			name:     "Hash separator with a value not matching the pattern",
+			msg:      "feat: add parser\n\nRefs #PROJ",
+			check:    checkFooterValue,
+			expected: []string{`Footer 'Refs' value must match #\d+|PROJ-\d+`},
+		},
+		{
+			name:     "Missing required footer",
+			msg:      "feat: add parser\n\nReviewed-by: Jane Doe <jane@example.com>",
+			check:    checkFooterRequired,
+			expected: []string{"Footer 'Refs' is required"},
+		},
+		{
+			name:     "Repeated unique footer",
+			msg:      "feat: add parser\n\nRefs: #12\nrefs: #13",
+			check:    checkFooterUnique,
+			expected: []string{"Footer 'Refs' must not be repeated"},
+		},
+		{
+			name:  "Repeated footer that may repeat",
+			msg:   "feat: add parser\n\nReviewed-by: Jane Doe <jane@example.com>\nReviewed-by: John Doe <john@example.com>\nRefs: #12",
+			check: checkFooterUnique,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := tt.check(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("check() = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+
+	for _, check := range []checkFunc{checkFooterEnum, checkFooterValue, checkFooterRequired, checkFooterUnique} {
+		if violations := check(parseCommit("feat: add parser\n\nDeployment: staging", ": "), defaultConfig); len(violations) != 0 {
+			t.Errorf("check() without declared footers = %v, want none", violations)
+		}
+	}
+}
+
+func TestCheckFooterFormatLowercaseToken(t *testing.T) {
+	violations := checkFooterFormat(parseCommit("feat: add parser\n\nRefs: #12\nco-authored-by:Jane Doe <jane@example.com>", ": "), defaultConfig)
+	if len(violations) != 1 || violations[0].Line != 4 {
+		t.Errorf("checkFooterFormat() = %v, want a violation on line 4", violations)
+	}
+}
+
+func TestValidateFooters(t *testing.T) {
+	tests := []struct {
+		name    string
+		footers FootersConfig
+		wantErr bool
+	}{
+		{name: "Valid", footers: FootersConfig{Tokens: []FooterConfig{{Token: "Refs", Pattern: `#\d+`}}}},
+		{name: "Invalid token", footers: FootersConfig{Tokens: []FooterConfig{{Token: "Reviewed by"}}}, wantErr: true},
+		{name: "Duplicate token", footers: FootersConfig{Tokens: []FooterConfig{{Token: "Refs"}, {Token: "refs"}}}, wantErr: true},
+		{name: "Invalid pattern", footers: FootersConfig{Tokens: []FooterConfig{{Token: "Refs", Pattern: `(`}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFooters(tt.footers); (err != nil) != tt.wantErr {
+				t.Errorf("validateFooters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckFooterFormatSeparators(t *testing.T) {
+	tests := []struct {
+		name              string
+		msg               string
+		trailerSeparators string
+		lines             []int
+	}{
+		{
+			name:  "Canonical separators",
+			msg:   "fix: handle nil\n\nRefs: #12\nCloses #13\nBREAKING CHANGE: nil is rejected",
+			lines: nil,
+		},
+		{
+			name:  "Missing space and whitespace before the separator",
+			msg:   "fix: handle nil\n\nRefs:#12\nAcked-by : Z\nReviewed-by: Y",
+			lines: []int{3, 4},
+		},
+		{
+			name:              "Configured separator",
+			msg:               "fix: handle nil\n\nRefs= #12\nReviewed-by: Y",
+			trailerSeparators: ":=",
+			lines:             nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []int
+			for _, v := range checkFooterFormat(parseCommitWith(tt.msg, ": ", tt.trailerSeparators), defaultConfig) {
+				lines = append(lines, v.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("checkFooterFormat() lines = %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
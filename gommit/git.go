@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// runGit runs a git command in the current directory and returns its trimmed
+// standard output. It is a variable so tests can stub it.
+var runGit = func(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func currentBranch() (string, error) {
+	return runGit("symbolic-ref", "--short", "-q", "HEAD")
+}
+
+// gitIdentity returns the configured user.name and user.email, used as the
+// committer identity.
+func gitIdentity() (string, string, error) {
+	name, err := runGit("config", "user.name")
+	if err != nil {
+		return "", "", err
+	}
+	email, err := runGit("config", "user.email")
+	if err != nil {
+		return "", "", err
+	}
+	return name, email, nil
+}
+
+// gitTrailerSeparators returns trailer.separators, or git's default ":".
+func gitTrailerSeparators() string {
+	separators, err := runGit("config", "trailer.separators")
+	if err != nil || separators == "" {
+		return defaultTrailerSeparators
+	}
+	return separators
+}
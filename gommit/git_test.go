@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubGit replaces runGit for the duration of a test. Commands are looked up
+// by their space-joined arguments; unknown commands fail.
+func stubGit(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	saved := runGit
+	t.Cleanup(func() { runGit = saved })
+	runGit = func(args ...string) (string, error) {
+		key := strings.Join(args, " ")
+		if out, ok := outputs[key]; ok {
+			return out, nil
+		}
+		return "", errors.New("unexpected git command: " + key)
+	}
+}
+
+func TestRunGit(t *testing.T) {
+	out, err := runGit("--version")
+	if err != nil {
+		t.Skipf("git is not available: %v", err)
+	}
+	if !strings.HasPrefix(out, "git version") {
+		t.Errorf("runGit() = %q, want a git version", out)
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	stubGit(t, map[string]string{"symbolic-ref --short -q HEAD": "feature/PROJ-1"})
+
+	branch, err := currentBranch()
+	if err != nil || branch != "feature/PROJ-1" {
+		t.Errorf("currentBranch() = %q, %v", branch, err)
+	}
+}
+
+func TestGitIdentity(t *testing.T) {
+	stubGit(t, map[string]string{
+		"config user.name":  "Jane Doe",
+		"config user.email": "jane@example.com",
+	})
+
+	name, email, err := gitIdentity()
+	if err != nil || name != "Jane Doe" || email != "jane@example.com" {
+		t.Errorf("gitIdentity() = %q, %q, %v", name, email, err)
+	}
+
+	stubGit(t, map[string]string{"config user.name": "Jane Doe"})
+	if _, _, err := gitIdentity(); err == nil {
+		t.Error("gitIdentity() without user.email did not fail")
+	}
+}
+
+func TestGitTrailerSeparators(t *testing.T) {
+	stubGit(t, map[string]string{"config trailer.separators": ":#"})
+	if separators := gitTrailerSeparators(); separators != ":#" {
+		t.Errorf("gitTrailerSeparators() = %q, want %q", separators, ":#")
+	}
+
+	stubGit(t, map[string]string{})
+	if separators := gitTrailerSeparators(); separators != ":" {
+		t.Errorf("gitTrailerSeparators() without configuration = %q, want %q", separators, ":")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultIgnores match messages generated by git itself, which are skipped
+// unless disable_default_ignores is set.
+var defaultIgnores = []struct {
+	reason  string
+	pattern *regexp.Regexp
+}{
+	{"merge commit", regexp.MustCompile(`^Merge (branch|branches|tag|remote-tracking branch|pull request|commit) `)},
+	{"merge commit", regexp.MustCompile(`^Merged? \S+ into \S+`)},
+	{"revert commit", regexp.MustCompile(`^(Revert|Reapply) "`)},
+	{"fixup commit", regexp.MustCompile(`^fixup! `)},
+	{"squash commit", regexp.MustCompile(`^squash! `)},
+	{"amend commit", regexp.MustCompile(`^amend! `)},
+}
+
+// ignoreReason reports whether msg must be skipped, and why. Patterns are
+// matched against the whole trimmed message, so "^" anchors to the header.
+func ignoreReason(msg string, config Config) (string, bool) {
+	msg = strings.TrimSpace(msg)
+	if !config.DisableDefaultIgnores {
+		for _, ignore := range defaultIgnores {
+			if ignore.pattern.MatchString(msg) {
+				return "git-generated " + ignore.reason, true
+			}
+		}
+	}
+	for _, ignore := range config.Ignores {
+		if pattern, err := regexp.Compile(ignore); err == nil && pattern.MatchString(msg) {
+			return fmt.Sprintf("message matches ignore pattern %q", ignore), true
+		}
+	}
+	return "", false
+}
+
+func validateIgnores(ignores []string) error {
+	for _, ignore := range ignores {
+		if _, err := regexp.Compile(ignore); err != nil {
+			return fmt.Errorf("invalid ignores entry %q: %w", ignore, err)
+		}
+	}
+	return nil
+}
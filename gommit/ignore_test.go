@@ -0,0 +1,82 @@
+package main
+
+import "testing"
+
+func TestIgnoreReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		config   Config
+		expected string
+		ignored  bool
+	}{
+		{
+			name:     "Merge branch",
+			msg:      "Merge branch 'feature/parser' into main\n",
+			expected: "git-generated merge commit",
+			ignored:  true,
+		},
+		{
+			name:     "Merge pull request",
+			msg:      "Merge pull request #12 from moukrea/parser\n\nfeat: add parser",
+			expected: "git-generated merge commit",
+			ignored:  true,
+		},
+		{
+			name:     "Revert",
+			msg:      "Revert \"feat: add parser\"\n\nThis reverts commit 1a2b3c4d.",
+			expected: "git-generated revert commit",
+			ignored:  true,
+		},
+		{
+			name:     "Fixup",
+			msg:      "fixup! feat: add parser",
+			expected: "git-generated fixup commit",
+			ignored:  true,
+		},
+		{
+			name:     "Squash",
+			msg:      "squash! feat: add parser\n\nMore details.",
+			expected: "git-generated squash commit",
+			ignored:  true,
+		},
+		{
+			name: "Conventional commit",
+			msg:  "feat: merge branch handling",
+		},
+		{
+			name: "Merge in the body only",
+			msg:  "fix: handle conflicts\n\nMerge branch 'x' used to fail.",
+		},
+		{
+			name:   "Default ignores disabled",
+			msg:    "fixup! feat: add parser",
+			config: Config{DisableDefaultIgnores: true},
+		},
+		{
+			name:     "Custom pattern",
+			msg:      "Release 1.2.0",
+			config:   Config{Ignores: []string{`^Release \d+\.\d+\.\d+$`}},
+			expected: `message matches ignore pattern "^Release \\d+\\.\\d+\\.\\d+$"`,
+			ignored:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ignored := ignoreReason(tt.msg, tt.config)
+			if reason != tt.expected || ignored != tt.ignored {
+				t.Errorf("ignoreReason() = %q, %v, want %q, %v", reason, ignored, tt.expected, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestValidateIgnores(t *testing.T) {
+	if err := validateIgnores([]string{`^WIP`, `^Release `}); err != nil {
+		t.Errorf("validateIgnores() error = %v", err)
+	}
+	if err := validateIgnores([]string{`^(WIP`}); err == nil {
+		t.Error("validateIgnores() accepted an invalid pattern")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+const (
+	lengthUnitBytes = "bytes"
+	lengthUnitRunes = "runes"
+	lengthUnitWidth = "width"
+)
+
+var lengthUnits = []string{lengthUnitBytes, lengthUnitRunes, lengthUnitWidth}
+
+// textLength measures s in the configured unit. "width" is the terminal
+// display width: wide characters count as two columns and a grapheme
+// cluster, such as an emoji with modifiers, counts once.
+func textLength(s, unit string) int {
+	switch unit {
+	case lengthUnitBytes:
+		return len(s)
+	case lengthUnitRunes:
+		return utf8.RuneCountInString(s)
+	default:
+		return uniseg.StringWidth(s)
+	}
+}
+
+// lengthOffset returns the byte offset of the first character that takes s
+// beyond limit, or len(s) when s fits.
+func lengthOffset(s string, limit int, unit string) int {
+	switch unit {
+	case lengthUnitBytes:
+		return min(limit, len(s))
+	case lengthUnitRunes:
+		offset := 0
+		for i := 0; i < limit && offset < len(s); i++ {
+			_, size := utf8.DecodeRuneInString(s[offset:])
+			offset += size
+		}
+		return offset
+	default:
+		offset, total, state := 0, 0, -1
+		rest := s
+		for rest != "" {
+			var cluster string
+			var width int
+			cluster, rest, width, state = uniseg.FirstGraphemeClusterInString(rest, state)
+			if total+width > limit {
+				break
+			}
+			total += width
+			offset += len(cluster)
+		}
+		return offset
+	}
+}
+
+func validateLengthUnit(unit string) error {
+	if !contains(lengthUnits, unit) {
+		return fmt.Errorf("invalid length_unit %q (expected %s)", unit, strings.Join(lengthUnits, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextLength(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		bytes int
+		runes int
+		width int
+	}{
+		{"ASCII", "fix: typo", 9, 9, 9},
+		{"Accented", "fix: café", 10, 9, 9},
+		{"CJK", "docs: 日本語", 15, 9, 12},
+		{"Emoji with skin tone", "feat: 👍🏽", 14, 8, 8},
+		{"Combining mark", "fix: é", 8, 7, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := textLength(tt.text, lengthUnitBytes); result != tt.bytes {
+				t.Errorf("textLength(bytes) = %d, want %d", result, tt.bytes)
+			}
+			if result := textLength(tt.text, lengthUnitRunes); result != tt.runes {
+				t.Errorf("textLength(runes) = %d, want %d", result, tt.runes)
+			}
+			if result := textLength(tt.text, lengthUnitWidth); result != tt.width {
+				t.Errorf("textLength(width) = %d, want %d", result, tt.width)
+			}
+		})
+	}
+}
+
+func TestLengthOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		limit    int
+		unit     string
+		expected int
+	}{
+		{"Fits", "abc", 5, lengthUnitWidth, 3},
+		{"Bytes", "abcdef", 4, lengthUnitBytes, 4},
+		{"Runes", "ééé", 2, lengthUnitRunes, 4},
+		{"Wide character does not fit", "日本語", 5, lengthUnitWidth, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := lengthOffset(tt.text, tt.limit, tt.unit); result != tt.expected {
+				t.Errorf("lengthOffset() = %d, want %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnicodeLengthRules(t *testing.T) {
+	// 50 visible characters, but well over 50 bytes.
+	header := "docs: " + strings.Repeat("é", 44)
+
+	config := defaultConfig
+	if violations := validateCommitMsg(header, config); hasViolation(violations, "header-max-length") {
+		t.Errorf("validateCommitMsg() with width = %v, want no header-max-length", violations)
+	}
+
+	config.LengthUnit = lengthUnitBytes
+	if violations := validateCommitMsg(header, config); !hasViolation(violations, "header-max-length") {
+		t.Errorf("validateCommitMsg() with bytes = %v, want header-max-length", violations)
+	}
+}
+
+func TestUnicodeDescriptionCase(t *testing.T) {
+	tests := []struct {
+		msg   string
+		fails bool
+		fixed string
+	}{
+		{msg: "fix: Élan handling", fails: true, fixed: "fix: élan handling"},
+		{msg: "fix: élan handling"},
+		{msg: "fix: 日本語 support"},
+	}
+
+	// With any other subject_case, subject-case reports the first word.
+	config := defaultConfig
+	config.SubjectCase = subjectCaseAny
+	for _, tt := range tests {
+		violations := checkDescriptionCase(parseCommit(tt.msg, ": "), config)
+		if (len(violations) > 0) != tt.fails {
+			t.Errorf("checkDescriptionCase(%q) = %v, want failure %v", tt.msg, violations, tt.fails)
+			continue
+		}
+		if tt.fails {
+			if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+				t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+			}
+		}
+	}
+}
@@ -25,61 +25,73 @@ var (
 	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
 	headerStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true)
 	detailStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500"))
 )
 
 var (
-	typePattern           = `^(feat|fix|build|chore|ci|docs|style|refactor|perf|test)`
-	scopePattern          = `(\([a-z0-9\-]+\))?`
-	breakingChangeMarker  = `!?`
-	descriptionPattern    = `: .+`
-	headerPattern         = regexp.MustCompile(typePattern + scopePattern + breakingChangeMarker + descriptionPattern + `$`)
-	footerPattern         = regexp.MustCompile(`^([A-Z\-]+)(\s+)?:(\s+)?(.+)$`)
-	breakingChangePattern = regexp.MustCompile(`^BREAKING[\s-]CHANGE: `)
+	breakingChangeMarker = `!?`
+	descriptionPattern   = `.+`
 )
 
-type Rule struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-}
-
 type Config struct {
-	DisabledRules     []string `yaml:"disabled_rules"`
-	HeaderMaxLength   int      `yaml:"header_max_length"`
-	BodyLineMaxLength int      `yaml:"body_line_max_length"`
-	AllowedTypes      []string `yaml:"allowed_types"`
+	DisabledRules         []string            `yaml:"disabled_rules"`
+	Rules                 map[string]Severity `yaml:"rules"`
+	HeaderMaxLength       int                 `yaml:"header_max_length"`
+	BodyLineMaxLength     int                 `yaml:"body_line_max_length"`
+	BodyLineIgnoreURLs    bool                `yaml:"body_line_ignore_urls"`
+	BodyLineIgnoreCode    bool                `yaml:"body_line_ignore_code"`
+	FooterMaxLineLength   int                 `yaml:"footer_max_line_length"`
+	SubjectMinLength      int                 `yaml:"subject_min_length"`
+	SubjectFullStop       string              `yaml:"subject_full_stop"`
+	BodyMinLength         int                 `yaml:"body_min_length"`
+	BodyMaxLength         int                 `yaml:"body_max_length"`
+	BodyRequiredTypes     []string            `yaml:"body_required_types"`
+	LengthUnit            string              `yaml:"length_unit"`
+	SubjectCase           string              `yaml:"subject_case"`
+	SubjectCaseExceptions []string            `yaml:"subject_case_exceptions"`
+	AllowedTypes          []string            `yaml:"allowed_types"`
+	AllowedScopes         []ScopeNode         `yaml:"allowed_scopes"`
+	TypeAliases           map[string]string   `yaml:"type_aliases"`
+	ScopeAliases          map[string]string   `yaml:"scope_aliases"`
+	ScopeRequiredTypes    []string            `yaml:"scope_required_types"`
+	CustomRules           []CustomRule        `yaml:"custom_rules"`
+	Footers               FootersConfig       `yaml:"footers"`
+	BreakingChangeToken   string              `yaml:"breaking_change_token"`
+	ReferenceToken        string              `yaml:"reference_token"`
+	ReferencePattern      string              `yaml:"reference_pattern"`
+	ScopeCharacters       string              `yaml:"scope_characters"`
+	HeaderSeparator       string              `yaml:"header_separator"`
+	TrailerSeparators     string              `yaml:"trailer_separators"`
+	Ignores               []string            `yaml:"ignores"`
+	DisableDefaultIgnores bool                `yaml:"disable_default_ignores"`
 }
 
 var defaultConfig = Config{
-	HeaderMaxLength:   50,
-	BodyLineMaxLength: 72,
+	HeaderMaxLength:     50,
+	BodyLineMaxLength:   72,
+	BodyLineIgnoreURLs:  true,
+	BodyLineIgnoreCode:  true,
+	FooterMaxLineLength: 100,
+	SubjectFullStop:     ".",
+	LengthUnit:          lengthUnitWidth,
+	SubjectCase:         subjectCaseLower,
 	AllowedTypes: []string{
 		"feat", "fix", "docs", "style", "refactor",
 		"perf", "test", "build", "ci", "chore", "revert",
 	},
-}
-
-var defaultRules = []Rule{
-	{Name: "header-format", Description: "Header must be in format: <type>[optional scope][!]: <description>"},
-	{Name: "header-max-length", Description: "Header must not exceed the configured max length"},
-	{Name: "header-lowercase", Description: "Header (short description) must be all lowercase"},
-	{Name: "description-case", Description: "Description must start with lowercase"},
-	{Name: "body-line-max-length", Description: "Body lines must not exceed the configured max length"},
-	{Name: "footer-format", Description: "Footer must be in format: <token>: <value>"},
-	{Name: "breaking-change", Description: "Breaking changes must be indicated in footer"},
-	{Name: AUTO_BREAKING_CHANGE, Description: "Automatically add BREAKING CHANGE to footer when '!' is present in header"},
-	{Name: "type-enum", Description: "Type must be one of the allowed types"},
-	{Name: "type-case", Description: "Type must be in lowercase"},
-	{Name: "type-empty", Description: "Type must not be empty"},
-	{Name: "scope-case", Description: "Scope must be in lowercase"},
-	{Name: "subject-empty", Description: "Subject must not be empty"},
+	ReferenceToken:   "Refs",
+	ReferencePattern: `[A-Z][A-Z0-9]+-\d+`,
+	ScopeCharacters:  `a-z0-9\-`,
+	HeaderSeparator:  ": ",
 }
 
 type model struct {
-	textInput textinput.Model
-	err       error
+	textInput  textinput.Model
+	violations []Violation
+	err        error
 }
 
-func initialModel(initialContent string) model {
+func initialModel(initialContent string, violations []Violation) model {
 	ti := textinput.New()
 	ti.Placeholder = "Edit your commit message"
 	ti.Focus()
@@ -88,8 +100,9 @@ func initialModel(initialContent string) model {
 	ti.SetValue(initialContent)
 
 	return model{
-		textInput: ti,
-		err:       nil,
+		textInput:  ti,
+		violations: violations,
+		err:        nil,
 	}
 }
 
@@ -119,8 +132,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() string {
+	var issues strings.Builder
+	for _, v := range m.violations {
+		issues.WriteString(violationStyle(v).Render(formatViolation(v)) + "\n")
+	}
+
 	return fmt.Sprintf(
-		"Edit your commit message:\n\n%s\n\n%s",
+		"%s\nEdit your commit message:\n\n%s\n\n%s",
+		issues.String(),
 		m.textInput.View(),
 		"(Press Enter to save, or Esc to cancel)",
 	) + "\n"
@@ -182,131 +201,180 @@ func loadConfig(pathGetter ConfigPathGetter) (Config, error) {
 	if config.BodyLineMaxLength == 0 {
 		config.BodyLineMaxLength = defaultConfig.BodyLineMaxLength
 	}
+	if config.FooterMaxLineLength == 0 {
+		config.FooterMaxLineLength = defaultConfig.FooterMaxLineLength
+	}
+	if config.SubjectFullStop == "" {
+		config.SubjectFullStop = defaultConfig.SubjectFullStop
+	}
+	if config.LengthUnit == "" {
+		config.LengthUnit = defaultConfig.LengthUnit
+	}
+	if config.SubjectCase == "" {
+		config.SubjectCase = defaultConfig.SubjectCase
+	}
 	if len(config.AllowedTypes) == 0 {
 		config.AllowedTypes = defaultConfig.AllowedTypes
 	}
 
-	return config, nil
-}
+	if config.ReferenceToken == "" {
+		config.ReferenceToken = defaultConfig.ReferenceToken
+	}
+	if config.ReferencePattern == "" {
+		config.ReferencePattern = defaultConfig.ReferencePattern
+	}
+	if config.ScopeCharacters == "" {
+		config.ScopeCharacters = defaultConfig.ScopeCharacters
+	}
+	if config.HeaderSeparator == "" {
+		config.HeaderSeparator = defaultConfig.HeaderSeparator
+	}
 
-func isRuleEnabled(config Config, ruleName string) bool {
-	for _, disabledRule := range config.DisabledRules {
-		if disabledRule == ruleName {
-			return false
-		}
+	if err := validateLengthUnit(config.LengthUnit); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateAliases(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateFooters(config.Footers); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateIgnores(config.Ignores); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateSubjectCase(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateBreakingChangeToken(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if _, err := regexp.Compile(config.ReferencePattern); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: invalid reference_pattern: %w", err)
+	}
+	if _, err := buildHeaderPattern(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateCustomRules(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if err := validateRuleSeverities(config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file: %w", err)
 	}
-	return true
-}
 
-func validateCommitMsg(msg string, config Config) ([]string, bool) {
-	var errors []string
-	needsBreakingChange := false
+	return config, nil
+}
 
-	msg = strings.TrimSpace(msg)
-	if msg == "" {
-		errors = append(errors, "Commit message is empty")
-		return errors, needsBreakingChange
+// buildHeaderPattern builds the header-format grammar from the allowed types,
+// the scope character class and the header separator.
+func buildHeaderPattern(config Config) (*regexp.Regexp, error) {
+	types := make([]string, len(config.AllowedTypes))
+	for i, t := range config.AllowedTypes {
+		types[i] = regexp.QuoteMeta(t)
+	}
+	scopeCharacters := config.ScopeCharacters
+	if scopeCharacters == "" {
+		scopeCharacters = defaultConfig.ScopeCharacters
 	}
 
-	lines := strings.Split(msg, "\n")
-	header := lines[0]
-	headerParts := strings.SplitN(header, ": ", 2)
+	typePattern := `^(` + strings.Join(types, "|") + `)`
+	scopeSegment := `[` + scopeCharacters + `]+`
+	scopeElement := scopeSegment + `(` + regexp.QuoteMeta(scopePathSeparator) + scopeSegment + `)*`
+	scopePattern := `(\(` + scopeElement + `(` + regexp.QuoteMeta(scopeListSeparator) + ` ?` + scopeElement + `)*\))?`
+	separatorPattern := regexp.QuoteMeta(headerSeparator(config))
 
-	// Rule: header-format
-	if isRuleEnabled(config, "header-format") && !headerPattern.MatchString(header) {
-		errors = append(errors, "Header must be in format: <type>[optional scope][!]: <description>")
+	pattern, err := regexp.Compile(typePattern + scopePattern + breakingChangeMarker + separatorPattern + descriptionPattern + `$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header grammar: %w", err)
 	}
+	return pattern, nil
+}
 
-	// Rule: header-max-length
-	if isRuleEnabled(config, "header-max-length") && len(header) > config.HeaderMaxLength {
-		errors = append(errors, fmt.Sprintf("Header must not exceed %d characters", config.HeaderMaxLength))
+func headerSeparator(config Config) string {
+	if config.HeaderSeparator == "" {
+		return defaultConfig.HeaderSeparator
 	}
+	return config.HeaderSeparator
+}
 
-	// Rule: header-lowercase
-	if isRuleEnabled(config, "header-lowercase") && strings.ToLower(header) != header {
-		errors = append(errors, "Header (short description) must be all lowercase")
-	}
+func isRuleEnabled(config Config, ruleName string) bool {
+	return ruleSeverity(config, ruleName) != SeverityOff
+}
 
-	// Rule: type-enum
-	if isRuleEnabled(config, "type-enum") && len(headerParts) > 0 {
-		typeScope := strings.Split(headerParts[0], "(")
-		commitType := strings.TrimSuffix(typeScope[0], "!") // Remove '!' if present
-		if !contains(config.AllowedTypes, commitType) {
-			errors = append(errors, fmt.Sprintf("Type '%s' is not allowed. Allowed types are: %s", commitType, strings.Join(config.AllowedTypes, ", ")))
+// ruleSeverity resolves the effective severity of a rule: disabled_rules wins,
+// then the rules map, then the rule's default.
+func ruleSeverity(config Config, ruleName string) Severity {
+	names := []string{ruleName}
+	for legacy, current := range legacyRuleNames {
+		if current == ruleName {
+			names = append(names, legacy)
 		}
 	}
 
-	// Rule: type-case
-	if isRuleEnabled(config, "type-case") && len(headerParts) > 0 {
-		typeScope := strings.Split(headerParts[0], "(")
-		commitType := typeScope[0]
-		if commitType != strings.ToLower(commitType) {
-			errors = append(errors, "Type must be in lowercase")
+	for _, name := range names {
+		if contains(config.DisabledRules, name) {
+			return SeverityOff
 		}
 	}
-
-	// Rule: type-empty
-	if isRuleEnabled(config, "type-empty") && len(headerParts) > 0 {
-		typeScope := strings.Split(headerParts[0], "(")
-		commitType := typeScope[0]
-		if commitType == "" {
-			errors = append(errors, "Type must not be empty")
+	for _, name := range names {
+		if severity, ok := config.Rules[name]; ok {
+			return severity
 		}
 	}
-
-	// Rule: scope-case
-	if isRuleEnabled(config, "scope-case") && len(headerParts) > 0 {
-		typeScope := strings.Split(headerParts[0], "(")
-		if len(typeScope) > 1 {
-			scope := strings.TrimRight(typeScope[1], ")")
-			if scope != strings.ToLower(scope) {
-				errors = append(errors, "Scope must be in lowercase")
-			}
-		}
+	if rule := lookupRule(ruleName); rule != nil {
+		return rule.DefaultSeverity()
 	}
-
-	// Rule: subject-empty
-	if isRuleEnabled(config, "subject-empty") && len(headerParts) < 2 {
-		errors = append(errors, "Subject must not be empty")
+	if rule := lookupCustomRule(config, ruleName); rule != nil {
+		return rule.DefaultSeverity()
 	}
+	return SeverityError
+}
 
-	// Rule: description-case
-	if isRuleEnabled(config, "description-case") && len(headerParts) == 2 && len(headerParts[1]) > 0 {
-		firstChar := headerParts[1][0]
-		if firstChar >= 'A' && firstChar <= 'Z' {
-			errors = append(errors, "Description must start with lowercase")
+func validateRuleSeverities(config Config) error {
+	for name, severity := range config.Rules {
+		if !isKnownRule(config, name) {
+			return fmt.Errorf("unknown rule %q in rules", name)
 		}
-	}
-
-	// Rule: body-line-max-length
-	if isRuleEnabled(config, "body-line-max-length") {
-		for i, line := range lines[1:] {
-			if len(line) > config.BodyLineMaxLength {
-				errors = append(errors, fmt.Sprintf("Body line %d exceeds %d characters", i+2, config.BodyLineMaxLength))
-			}
+		if !severity.valid() {
+			return fmt.Errorf("invalid severity %q for rule %q (expected error, warning or off)", severity, name)
 		}
 	}
+	return nil
+}
 
-	// Rule: footer-format
-	if isRuleEnabled(config, "footer-format") {
-		for i, line := range lines[1:] {
-			if footerPattern.MatchString(line) && !breakingChangePattern.MatchString(line) {
-				parts := strings.SplitN(line, ":", 2)
-				if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
-					errors = append(errors, fmt.Sprintf("Footer line %d must be in format: <token>: <value>", i+2))
-				}
-			}
-		}
+func isKnownRule(config Config, name string) bool {
+	if _, ok := legacyRuleNames[name]; ok {
+		return true
 	}
+	return lookupRule(name) != nil || lookupCustomRule(config, name) != nil
+}
 
-	// Rule: breaking-change
-	if isRuleEnabled(config, "breaking-change") {
-		if strings.Contains(header, "!") && !containsBreakingChange(lines[1:]) {
-			needsBreakingChange = true
+func validateCommitMsg(msg string, config Config) []Violation {
+	var violations []Violation
+
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		violations = append(violations, Violation{
+			Rule: "subject-empty", Severity: SeverityError,
+			Line: 1, Column: 1, EndColumn: 1,
+			Message: "Commit message is empty",
+		})
+		return violations
+	}
+
+	commit := parseCommitWith(msg, headerSeparator(config), config.TrailerSeparators)
+	for _, rule := range activeRules(config) {
+		severity := ruleSeverity(config, rule.Name())
+		if severity == SeverityOff {
+			continue
+		}
+		for _, v := range rule.Check(commit, config) {
+			v.Rule = rule.Name()
+			v.Severity = severity
+			violations = append(violations, v)
 		}
 	}
 
-	return errors, needsBreakingChange
+	return violations
 }
 
 func contains(slice []string, item string) bool {
@@ -318,35 +386,108 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-func containsBreakingChange(footerLines []string) bool {
-	for _, line := range footerLines {
-		if breakingChangePattern.MatchString(line) {
-			return true
+func promptForBreakingChange() string {
+	fmt.Print("Describe briefly the breaking change (leave empty to edit the message instead): ")
+	description, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(description)
+}
+
+func appendBreakingChange(msg, token, description string) string {
+	return strings.TrimSpace(appendFooter(msg, token, description))
+}
+
+// appendFooter adds a "<token>: <value>" footer, joining the existing footer
+// block when there is one.
+func appendFooter(msg, token, value string) string {
+	msg = strings.TrimSpace(msg)
+	footer := token + ": " + value
+	if parseCommit(msg, "").FooterStart == 0 {
+		return msg + "\n\n" + footer
+	}
+	return msg + "\n" + footer
+}
+
+func promptYesNo(question string) bool {
+	fmt.Printf("%s [Y/n]: ", question)
+	var response string
+	fmt.Scanln(&response)
+	return response == "" || response == "y" || response == "Y"
+}
+
+// offerFix asks whether to apply the fix of the first violation of ruleName.
+// It returns the updated message and whether the fix was applied.
+func offerFix(msg string, violations []Violation, ruleName string) (string, bool) {
+	for _, v := range violations {
+		if v.Rule != ruleName || v.Fix == nil {
+			continue
+		}
+		fmt.Println(violationStyle(v).Render(formatViolation(v)))
+		if !promptYesNo(v.Fix.Description + "?") {
+			return msg, false
 		}
+		return v.Fix.Apply(strings.TrimSpace(msg)), true
 	}
-	return false
+	return msg, false
 }
 
-func promptForBreakingChange() string {
-	fmt.Print("Describe briefly the breaking change (leave empty for no description): ")
-	var description string
-	fmt.Scanln(&description)
-	return description
+// offerFixes lists the fixable violations of a rejected message and asks
+// whether to apply all of them, saving a round-trip through the editor.
+func offerFixes(msg string, violations []Violation, config Config) (string, bool) {
+	if !hasErrors(violations) {
+		return msg, false
+	}
+	var fixable []Violation
+	for _, v := range violations {
+		if v.Fix != nil {
+			fixable = append(fixable, v)
+		}
+	}
+	if len(fixable) == 0 {
+		return msg, false
+	}
+	for _, v := range fixable {
+		fmt.Println(violationStyle(v).Render(formatViolation(v)))
+	}
+	if !promptYesNo("Apply the suggested fixes?") {
+		return msg, false
+	}
+	fixed, applied := applyFixes(msg, config)
+	return fixed, len(applied) > 0
 }
 
-func appendBreakingChange(msg, description string) string {
-	lines := strings.Split(msg, "\n")
-	breakingChangeFooter := "BREAKING CHANGE: " + description
+// validateInteractively validates msg, rewriting type and scope aliases and
+// prompting the user for the footers Gommit can add on their behalf. It
+// returns the resulting message along with its remaining violations.
+func validateInteractively(msg string, config Config) (string, []Violation) {
+	if fixed, applied := applyFixes(msg, config, "type-alias", "scope-alias", "change-id"); len(applied) > 0 {
+		for _, v := range applied {
+			fmt.Println(successStyle.Render("✔ " + v.Fix.Description))
+		}
+		msg = fixed
+	}
+
+	violations := validateCommitMsg(msg, config)
+
+	if hasViolation(violations, "breaking-change") && isRuleEnabled(config, AUTO_BREAKING_CHANGE) {
+		if description := promptForBreakingChange(); description != "" {
+			msg = appendBreakingChange(msg, breakingChangeToken(config), description)
+			violations = validateCommitMsg(msg, config) // Revalidate after adding BREAKING CHANGE
+		}
+	}
 
-	// Ensure there's an empty line before adding the breaking change
-	if len(lines) > 0 && lines[len(lines)-1] != "" {
-		lines = append(lines, "")
+	for _, ruleName := range []string{"references-empty", "signed-off-by"} {
+		if fixed, ok := offerFix(msg, violations, ruleName); ok {
+			msg = fixed
+			violations = validateCommitMsg(msg, config)
+		}
 	}
 
-	// Add the breaking change footer
-	lines = append(lines, breakingChangeFooter)
+	if fixed, ok := offerFixes(msg, violations, config); ok {
+		msg = fixed
+		violations = validateCommitMsg(msg, config)
+	}
 
-	return strings.TrimSpace(strings.Join(lines, "\n"))
+	return msg, violations
 }
 
 func readFromStdin() (string, error) {
@@ -396,6 +537,9 @@ func runGommit(pathGetter ConfigPathGetter) error {
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
+	if config.TrailerSeparators == "" {
+		config.TrailerSeparators = gitTrailerSeparators()
+	}
 
 	var commitMsgFile string
 
@@ -424,57 +568,55 @@ func runGommit(pathGetter ConfigPathGetter) error {
 	if err != nil {
 		return fmt.Errorf("failed to read commit message file: %w", err)
 	}
+	cleanup := gitCleanup(originalMsg)
+	originalMsg = cleanup.Apply(originalMsg)
 
-	commitMsg := originalMsg
-	errors, needsBreakingChange := validateCommitMsg(commitMsg, config)
-
-	if needsBreakingChange && isRuleEnabled(config, AUTO_BREAKING_CHANGE) {
-		description := promptForBreakingChange()
-		commitMsg = appendBreakingChange(commitMsg, description)
-		errors, _ = validateCommitMsg(commitMsg, config) // Revalidate after adding BREAKING CHANGE
+	if reason, ok := ignoreReason(originalMsg, config); ok {
+		fmt.Println(headerStyle.Render(fmt.Sprintf("Skipping validation: %s.", reason)))
+		return nil
 	}
 
-	if len(errors) > 0 {
+	commitMsg, violations := validateInteractively(originalMsg, config)
+
+	if hasErrors(violations) {
 		fmt.Println(errorStyle.Render(COMMIT_MSG_INVALID_MSG))
-		fmt.Println(errorStyle.Render("Commit message does not follow the configured rules:"))
-		for _, err := range errors {
-			fmt.Println(detailStyle.Render(fmt.Sprintf("  • %s", err)))
-		}
+		fmt.Println(errorStyle.Render("Commit message does not follow the configured rules."))
 		fmt.Println(headerStyle.Render("Please edit your commit message to follow the rules:"))
 
-		p := tea.NewProgram(initialModel(commitMsg))
+		p := tea.NewProgram(initialModel(commitMsg, violations))
 		m, err := p.Run()
 		if err != nil {
 			return fmt.Errorf("error running text input program: %w", err)
 		}
 
-		commitMsg = m.(model).textInput.Value()
-		if commitMsg == originalMsg {
+		editedMsg := m.(model).textInput.Value()
+		if editedMsg == commitMsg {
 			fmt.Println(errorStyle.Render(COMMIT_MSG_INVALID_MSG))
 			fmt.Println(errorStyle.Render("Commit message was not modified."))
 			return fmt.Errorf("commit message validation failed")
 		}
 
 		// Re-validate the edited commit message
-		errors, needsBreakingChange = validateCommitMsg(commitMsg, config)
+		commitMsg, violations = validateInteractively(editedMsg, config)
 
-		if needsBreakingChange && isRuleEnabled(config, AUTO_BREAKING_CHANGE) {
-			description := promptForBreakingChange()
-			commitMsg = appendBreakingChange(commitMsg, description)
-			errors, _ = validateCommitMsg(commitMsg, config) // Revalidate after adding BREAKING CHANGE
-		}
-
-		if len(errors) > 0 {
+		if hasErrors(violations) {
 			fmt.Println(errorStyle.Render(COMMIT_MSG_INVALID_MSG))
 			fmt.Println(errorStyle.Render("Commit message is still invalid:"))
-			for _, err := range errors {
-				fmt.Println(detailStyle.Render(fmt.Sprintf("  • %s", err)))
+			for _, v := range violations {
+				fmt.Println(violationStyle(v).Render(formatViolation(v)))
 			}
 			return fmt.Errorf("commit message validation failed")
 		}
 	}
 
-	err = writeCommitMsg(commitMsgFile, commitMsg)
+	if warnings := filterSeverity(violations, SeverityWarning); len(warnings) > 0 {
+		fmt.Println(warningStyle.Render("⚠ Commit message has warnings:"))
+		for _, v := range warnings {
+			fmt.Println(warningStyle.Render(formatViolation(v)))
+		}
+	}
+
+	err = writeCommitMsg(commitMsgFile, cleanup.Apply(commitMsg))
 	if err != nil {
 		return fmt.Errorf("failed to write commit message: %w", err)
 	}
@@ -496,7 +638,12 @@ func main() {
 		}
 	}()
 
-	err := runGommit(DefaultConfigPathGetter{})
+	var err error
+	if len(os.Args) >= 2 && os.Args[1] == "check" {
+		err = runCheck(DefaultConfigPathGetter{}, os.Args[2:])
+	} else {
+		err = runGommit(DefaultConfigPathGetter{})
+	}
 	if err != nil {
 		fmt.Println(errorStyle.Render(COMMIT_MSG_INVALID_MSG))
 		fmt.Println(errorStyle.Render(err.Error()))
@@ -30,46 +30,59 @@ func TestValidateCommitMsg(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                string
-		msg                 string
-		expectedErrors      []string
-		needsBreakingChange bool
+		name           string
+		msg            string
+		expectedRules  []string
+		expectedErrors []string
 	}{
 		{
-			name:                "Valid commit message",
-			msg:                 COMMIT_MSG_EXAMPLE,
-			expectedErrors:      nil,
-			needsBreakingChange: false,
+			name:           "Valid commit message",
+			msg:            COMMIT_MSG_EXAMPLE,
+			expectedRules:  nil,
+			expectedErrors: nil,
 		},
 		{
-			name:                "Invalid type",
-			msg:                 "invalid: this is not a valid type",
-			expectedErrors:      []string{"Header must be in format: <type>[optional scope][!]: <description>", "Type 'invalid' is not allowed. Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
-			needsBreakingChange: false,
+			name:           "Invalid type",
+			msg:            "invalid: this is not a valid type",
+			expectedRules:  []string{"header-format", "type-enum"},
+			expectedErrors: []string{"Header must be in format: <type>[optional scope][!]: <description>", "Type 'invalid' is not allowed. Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
 		},
 		{
-			name:                "Header too long",
-			msg:                 "feat: this header is way too long and exceeds the maximum length",
-			expectedErrors:      []string{"Header must not exceed 50 characters"},
-			needsBreakingChange: false,
+			name:           "Header too long",
+			msg:            "feat: this header is way too long and exceeds the maximum length",
+			expectedRules:  []string{"header-max-length"},
+			expectedErrors: []string{"Header must not exceed 50 characters"},
 		},
 		{
-			name:                "Breaking change without footer",
-			msg:                 "feat!: add breaking change",
-			expectedErrors:      nil,
-			needsBreakingChange: true,
+			name:           "Breaking change without footer",
+			msg:            "feat!: add breaking change",
+			expectedRules:  []string{"breaking-change"},
+			expectedErrors: nil,
+		},
+		{
+			name:           "Empty message",
+			msg:            "  \n",
+			expectedRules:  []string{"subject-empty"},
+			expectedErrors: []string{"Commit message is empty"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors, needsBreakingChange := validateCommitMsg(tt.msg, config)
+			violations := validateCommitMsg(tt.msg, config)
+			var rules, errors []string
+			for _, v := range violations {
+				rules = append(rules, v.Rule)
+				if v.Severity == SeverityError {
+					errors = append(errors, v.Message)
+				}
+			}
+			if !reflect.DeepEqual(rules, tt.expectedRules) {
+				t.Errorf("validateCommitMsg() rules = %v, want %v", rules, tt.expectedRules)
+			}
 			if !reflect.DeepEqual(errors, tt.expectedErrors) {
 				t.Errorf("validateCommitMsg() errors = %v, want %v", errors, tt.expectedErrors)
 			}
-			if needsBreakingChange != tt.needsBreakingChange {
-				t.Errorf("validateCommitMsg() needsBreakingChange = %v, want %v", needsBreakingChange, tt.needsBreakingChange)
-			}
 		})
 	}
 }
@@ -108,9 +121,19 @@ allowed_types:
 
 	// Check if the loaded config matches the expected values
 	expectedConfig := Config{
-		HeaderMaxLength:   60,
-		BodyLineMaxLength: 80,
-		AllowedTypes:      []string{"feat", "fix", "docs"},
+		HeaderMaxLength:     60,
+		BodyLineMaxLength:   80,
+		BodyLineIgnoreURLs:  true,
+		BodyLineIgnoreCode:  true,
+		FooterMaxLineLength: defaultConfig.FooterMaxLineLength,
+		SubjectFullStop:     defaultConfig.SubjectFullStop,
+		LengthUnit:          defaultConfig.LengthUnit,
+		SubjectCase:         defaultConfig.SubjectCase,
+		AllowedTypes:        []string{"feat", "fix", "docs"},
+		ReferenceToken:      defaultConfig.ReferenceToken,
+		ReferencePattern:    defaultConfig.ReferencePattern,
+		ScopeCharacters:     defaultConfig.ScopeCharacters,
+		HeaderSeparator:     defaultConfig.HeaderSeparator,
 	}
 
 	if !reflect.DeepEqual(config, expectedConfig) {
@@ -118,34 +141,49 @@ allowed_types:
 	}
 }
 
-func TestContainsBreakingChange(t *testing.T) {
+func TestLoadConfigRuleSeverities(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", TEST_DIR)
+	if err != nil {
+		t.Fatalf(TEMP_DIR_CREATION_FAILURE, err)
+	}
+	defer os.RemoveAll(tempDir)
+
 	tests := []struct {
 		name     string
-		lines    []string
-		expected bool
+		content  string
+		expected map[string]Severity
+		wantErr  bool
 	}{
 		{
-			name:     "Contains breaking change",
-			lines:    []string{"Some text", "BREAKING CHANGE: This is a breaking change"},
-			expected: true,
+			name:     "Valid severities",
+			content:  "rules:\n  body-line-max-length: warning\n  header-lowercase: off\n",
+			expected: map[string]Severity{"body-line-max-length": SeverityWarning, "header-lowercase": SeverityOff},
 		},
 		{
-			name:     "No breaking change",
-			lines:    []string{"Some text", "This is not a breaking change"},
-			expected: false,
+			name:    "Invalid severity",
+			content: "rules:\n  header-format: fatal\n",
+			wantErr: true,
 		},
 		{
-			name:     "Empty lines",
-			lines:    []string{},
-			expected: false,
+			name:    "Unknown rule",
+			content: "rules:\n  no-such-rule: error\n",
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := containsBreakingChange(tt.lines)
-			if result != tt.expected {
-				t.Errorf("containsBreakingChange() = %v, want %v", result, tt.expected)
+			configPath := filepath.Join(tempDir, "gommit.conf.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write mock config file: %v", err)
+			}
+
+			config, err := loadConfig(MockConfigPathGetter{ConfigPath: configPath})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(config.Rules, tt.expected) {
+				t.Errorf("loadConfig() rules = %v, want %v", config.Rules, tt.expected)
 			}
 		})
 	}
@@ -155,6 +193,7 @@ func TestAppendBreakingChange(t *testing.T) {
 	tests := []struct {
 		name        string
 		msg         string
+		token       string
 		description string
 		expected    string
 	}{
@@ -168,13 +207,24 @@ func TestAppendBreakingChange(t *testing.T) {
 			name:        "Append to message with existing footer",
 			msg:         "feat!: add new feature\n\nReviewed-by: John Doe",
 			description: "This breaks the API",
-			expected:    "feat!: add new feature\n\nReviewed-by: John Doe\n\nBREAKING CHANGE: This breaks the API",
+			expected:    "feat!: add new feature\n\nReviewed-by: John Doe\nBREAKING CHANGE: This breaks the API",
+		},
+		{
+			name:        "Append with the hyphenated token",
+			msg:         "feat!: add new feature",
+			token:       "BREAKING-CHANGE",
+			description: "This breaks the API",
+			expected:    "feat!: add new feature\n\nBREAKING-CHANGE: This breaks the API",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := appendBreakingChange(tt.msg, tt.description)
+			token := tt.token
+			if token == "" {
+				token = "BREAKING CHANGE"
+			}
+			result := appendBreakingChange(tt.msg, token, tt.description)
 			if result != tt.expected {
 				t.Errorf("appendBreakingChange() = %v, want %v", result, tt.expected)
 			}
@@ -182,6 +232,39 @@ func TestAppendBreakingChange(t *testing.T) {
 	}
 }
 
+func TestAppendFooter(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{
+			name:     "Header only",
+			msg:      "feat: add login",
+			expected: "feat: add login\n\nRefs: PROJ-123",
+		},
+		{
+			name:     "Message with body",
+			msg:      "feat: add login\n\nAdds the login form.\n",
+			expected: "feat: add login\n\nAdds the login form.\n\nRefs: PROJ-123",
+		},
+		{
+			name:     "Existing footer block",
+			msg:      "feat: add login\n\nReviewed-by: Z",
+			expected: "feat: add login\n\nReviewed-by: Z\nRefs: PROJ-123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := appendFooter(tt.msg, "Refs", "PROJ-123")
+			if result != tt.expected {
+				t.Errorf("appendFooter() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestReadFromStdin(t *testing.T) {
 	// Save the original stdin
 	oldStdin := os.Stdin
@@ -341,9 +424,96 @@ Closes #123`,
 	}
 }
 
+func TestBuildHeaderPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		header  string
+		matches bool
+		wantErr bool
+	}{
+		{
+			name:    "Default grammar accepts revert",
+			config:  defaultConfig,
+			header:  "revert: feat: add parser",
+			matches: true,
+		},
+		{
+			name:    "Custom type",
+			config:  Config{AllowedTypes: []string{"deps", "security"}},
+			header:  "security(auth): rotate keys",
+			matches: true,
+		},
+		{
+			name:    "Type not in allowed types",
+			config:  Config{AllowedTypes: []string{"deps"}},
+			header:  "feat: add parser",
+			matches: false,
+		},
+		{
+			name:    "Custom scope characters",
+			config:  Config{AllowedTypes: []string{"feat"}, ScopeCharacters: `a-z_`},
+			header:  "feat(user_auth): add login",
+			matches: true,
+		},
+		{
+			name:    "Multiple and hierarchical scopes",
+			config:  defaultConfig,
+			header:  "fix(api/auth, cli): refresh tokens",
+			matches: true,
+		},
+		{
+			name:    "Empty scope element",
+			config:  defaultConfig,
+			header:  "fix(api,): refresh tokens",
+			matches: false,
+		},
+		{
+			name:    "Custom separator",
+			config:  Config{AllowedTypes: []string{"feat"}, HeaderSeparator: " - "},
+			header:  "feat(api) - add endpoint",
+			matches: true,
+		},
+		{
+			name:    "Broken scope character class",
+			config:  Config{AllowedTypes: []string{"feat"}, ScopeCharacters: `z-a`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := buildHeaderPattern(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildHeaderPattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if pattern.MatchString(tt.header) != tt.matches {
+				t.Errorf("pattern %q matches %q = %v, want %v", pattern, tt.header, !tt.matches, tt.matches)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMsgCustomGrammar(t *testing.T) {
+	config := defaultConfig
+	config.AllowedTypes = append([]string{"deps"}, defaultConfig.AllowedTypes...)
+	config.HeaderSeparator = " - "
+
+	if violations := validateCommitMsg("deps(go) - bump yaml", config); len(violations) != 0 {
+		t.Errorf("validateCommitMsg() = %v, want no violations", violations)
+	}
+	if violations := validateCommitMsg("deps(go): bump yaml", config); !hasViolation(violations, "header-format") {
+		t.Errorf("validateCommitMsg() = %v, want a header-format violation", violations)
+	}
+}
+
 func TestIsRuleEnabled(t *testing.T) {
 	config := Config{
 		DisabledRules: []string{"rule1", "rule2"},
+		Rules:         map[string]Severity{"rule4": SeverityOff, "rule5": SeverityWarning},
 	}
 
 	tests := []struct {
@@ -361,6 +531,16 @@ func TestIsRuleEnabled(t *testing.T) {
 			ruleName: "rule3",
 			expected: true,
 		},
+		{
+			name:     "Rule set to off",
+			ruleName: "rule4",
+			expected: false,
+		},
+		{
+			name:     "Rule set to warning",
+			ruleName: "rule5",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -373,6 +553,65 @@ func TestIsRuleEnabled(t *testing.T) {
 	}
 }
 
+func TestRuleSeverity(t *testing.T) {
+	config := Config{
+		DisabledRules: []string{"header-lowercase"},
+		Rules: map[string]Severity{
+			"header-lowercase":     SeverityWarning,
+			"body-line-max-length": SeverityWarning,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		ruleName string
+		expected Severity
+	}{
+		{
+			name:     "Disabled rules take precedence",
+			ruleName: "header-lowercase",
+			expected: SeverityOff,
+		},
+		{
+			name:     "Legacy rule name applies to its successor",
+			ruleName: "subject-case",
+			expected: SeverityOff,
+		},
+		{
+			name:     "Configured severity",
+			ruleName: "body-line-max-length",
+			expected: SeverityWarning,
+		},
+		{
+			name:     "Default severity",
+			ruleName: "header-format",
+			expected: SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ruleSeverity(config, tt.ruleName)
+			if result != tt.expected {
+				t.Errorf("ruleSeverity() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMsgWarnings(t *testing.T) {
+	config := defaultConfig
+	config.Rules = map[string]Severity{"header-max-length": SeverityWarning}
+
+	violations := validateCommitMsg("feat: this header is way too long and exceeds the maximum length", config)
+	if len(violations) != 1 || violations[0].Severity != SeverityWarning {
+		t.Fatalf("validateCommitMsg() = %v, want a single warning", violations)
+	}
+	if hasErrors(violations) {
+		t.Error("hasErrors() = true, want false")
+	}
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		name     string
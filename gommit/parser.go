@@ -0,0 +1,392 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var footerStartPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9-]+)(: | #)`)
+
+// defaultTrailerSeparators is git's default for trailer.separators.
+const defaultTrailerSeparators = ":"
+
+// gitGeneratedPrefixes are the trailers git writes itself. A paragraph
+// holding one of them is a trailer block as soon as a quarter of its lines
+// are trailers.
+var gitGeneratedPrefixes = []string{"Signed-off-by: ", "(cherry picked from commit "}
+
+// Footer is a single "<token>: <value>" or "<token> #<value>" footer. Offset
+// is the byte offset of the token in the raw message and Line its 1-based
+// line number. Folded values are unfolded the way git does, with a single
+// space in place of each line break.
+type Footer struct {
+	Token     string
+	Separator string
+	Value     string
+	Offset    int
+	Line      int
+}
+
+// ParsedCommit is a commit message parsed according to the Conventional
+// Commits 1.0 grammar. Offsets in the header are byte offsets into Header.
+// Parsing never fails: when the header does not follow the grammar,
+// HeaderError describes the first problem and the fields are filled in on a
+// best-effort basis.
+type ParsedCommit struct {
+	Raw   string
+	Lines []string
+
+	Header            string
+	HeaderError       string
+	HeaderErrorOffset int
+
+	Type              string
+	Scope             string
+	Scopes            []string
+	HasScope          bool
+	ScopeOffset       int
+	Breaking          bool
+	BreakingOffset    int
+	Description       string
+	HasDescription    bool
+	DescriptionOffset int
+
+	// BodyStart and FooterStart are 1-based line numbers, 0 when absent.
+	Body           string
+	BodyParagraphs []string
+	BodyStart      int
+	Footers        []Footer
+	FooterStart    int
+
+	trailerSeparators string
+}
+
+// parseCommit parses msg using separator between the header prefix and the
+// description (": " in the Conventional Commits specification).
+func parseCommit(msg, separator string) *ParsedCommit {
+	return parseCommitWith(msg, separator, "")
+}
+
+// parseCommitWith is parseCommit with the characters git accepts between a
+// trailer token and its value, as set by trailer.separators.
+func parseCommitWith(msg, separator, trailerSeparators string) *ParsedCommit {
+	if separator == "" {
+		separator = defaultConfig.HeaderSeparator
+	}
+	if trailerSeparators == "" {
+		trailerSeparators = defaultTrailerSeparators
+	}
+	msg = strings.TrimSpace(msg)
+	lines := strings.Split(msg, "\n")
+	commit := &ParsedCommit{
+		Raw:               msg,
+		Lines:             lines,
+		Header:            lines[0],
+		trailerSeparators: trailerSeparators,
+	}
+
+	commit.parseHeader(separator)
+	commit.parseFooters()
+	commit.parseBody()
+
+	return commit
+}
+
+func (c *ParsedCommit) headerError(offset int, message string) {
+	if c.HeaderError == "" {
+		c.HeaderError = message
+		c.HeaderErrorOffset = offset
+	}
+}
+
+// parseHeader implements: type ["(" scope ")"] ["!"] separator description.
+func (c *ParsedCommit) parseHeader(separator string) {
+	header := c.Header
+	pos := strings.IndexAny(header, "(!: \t"+separator[:1])
+	if pos < 0 {
+		pos = len(header)
+	}
+	c.Type = header[:pos]
+	if c.Type == "" {
+		c.headerError(0, "missing type")
+	}
+
+	if pos < len(header) && header[pos] == '(' {
+		c.HasScope = true
+		c.ScopeOffset = pos + 1
+		end := strings.IndexByte(header[pos+1:], ')')
+		if end < 0 {
+			c.headerError(pos, "unclosed scope")
+			c.Scope = header[pos+1:]
+			pos = len(header)
+		} else {
+			c.Scope = header[pos+1 : pos+1+end]
+			if nested := strings.IndexByte(c.Scope, '('); nested >= 0 {
+				c.headerError(c.ScopeOffset+nested, "unexpected '(' in scope")
+			}
+			if c.Scope == "" {
+				c.headerError(pos, "empty scope")
+			}
+			pos += end + 2
+		}
+		for _, element := range splitScopes(c.Scope, c.ScopeOffset) {
+			c.Scopes = append(c.Scopes, element.Value)
+		}
+	}
+
+	if pos < len(header) && header[pos] == '!' {
+		c.Breaking = true
+		c.BreakingOffset = pos
+		pos++
+	}
+
+	rest := header[pos:]
+	trimmedSeparator := strings.TrimSpace(separator)
+	switch {
+	case strings.HasPrefix(rest, separator):
+		c.HasDescription = true
+		c.DescriptionOffset = pos + len(separator)
+		c.Description = header[c.DescriptionOffset:]
+	case trimmedSeparator != separator && trimmedSeparator != "" && strings.HasPrefix(rest, trimmedSeparator):
+		c.headerError(pos, fmt.Sprintf("expected a space after '%s'", trimmedSeparator))
+	case strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "\t"):
+		c.headerError(pos, fmt.Sprintf("unexpected whitespace before '%s'", trimmedSeparator))
+	default:
+		c.headerError(pos, fmt.Sprintf("expected '%s' after type", separator))
+	}
+
+	// Recover the description of malformed headers so that description
+	// rules can still report on it.
+	if !c.HasDescription {
+		if sep := strings.Index(header, separator); sep >= 0 {
+			c.HasDescription = true
+			c.DescriptionOffset = sep + len(separator)
+			c.Description = header[c.DescriptionOffset:]
+		}
+	}
+}
+
+// parseFooters extracts the footers the way git interpret-trailers does:
+// they are the last paragraph of the message, provided it is not the header
+// paragraph and either consists only of trailers, or holds a trailer git
+// generates itself and is at least a quarter trailers. Lines starting with
+// whitespace continue the previous trailer. Other lines of the block are not
+// footers.
+func (c *ParsedCommit) parseFooters() {
+	start := c.trailerBlockStart()
+	if start < 0 {
+		return
+	}
+	c.FooterStart = start + 1
+
+	offset := c.lineOffset(c.FooterStart)
+	var current *Footer
+	for i := start; i < len(c.Lines); i++ {
+		line := c.Lines[i]
+		switch {
+		case current != nil && isContinuationLine(line):
+			current.Value = strings.TrimSpace(current.Value + " " + strings.TrimSpace(line))
+		case c.trailerSeparator(line) >= 1:
+			sep := c.trailerSeparator(line)
+			valueStart := sep + 1
+			for valueStart < len(line) && (line[valueStart] == ' ' || line[valueStart] == '\t') {
+				valueStart++
+			}
+			token := strings.TrimRight(line[:sep], " \t")
+			c.Footers = append(c.Footers, Footer{
+				Token:     token,
+				Separator: line[len(token):valueStart],
+				Value:     strings.TrimSpace(line[valueStart:]),
+				Offset:    offset,
+				Line:      i + 1,
+			})
+			current = &c.Footers[len(c.Footers)-1]
+		default:
+			current = nil
+		}
+		offset += len(line) + 1
+	}
+}
+
+// trailerBlockStart returns the 0-based index of the first line of the
+// trailer block, or -1 when there is none. It follows git's
+// find_trailer_block_start.
+func (c *ParsedCommit) trailerBlockStart() int {
+	endOfTitle := len(c.Lines)
+	for i, line := range c.Lines {
+		if isBlankLine(line) {
+			endOfTitle = i
+			break
+		}
+	}
+
+	onlySpaces, recognizedPrefix := true, false
+	trailers, nonTrailers, continuations := 0, 0, 0
+	for i := len(c.Lines) - 1; i >= endOfTitle; i-- {
+		line := c.Lines[i]
+		switch {
+		case isBlankLine(line):
+			if onlySpaces {
+				continue
+			}
+			nonTrailers += continuations
+			if (recognizedPrefix && trailers*3 >= nonTrailers) || (trailers > 0 && nonTrailers == 0) {
+				return i + 1
+			}
+			return -1
+		case hasGitGeneratedPrefix(line):
+			trailers++
+			continuations = 0
+			recognizedPrefix = true
+		case c.trailerSeparator(line) >= 1:
+			trailers++
+			continuations = 0
+		case isContinuationLine(line):
+			continuations++
+		default:
+			nonTrailers += 1 + continuations
+			continuations = 0
+		}
+		onlySpaces = false
+	}
+	return -1
+}
+
+// strayTrailerStart returns the 1-based line of the trailers that end the
+// last paragraph without being recognised, because text precedes them in the
+// same paragraph; 0 when there are none.
+func (c *ParsedCommit) strayTrailerStart() int {
+	if c.FooterStart != 0 {
+		return 0
+	}
+	first := 1
+	for i := len(c.Lines) - 1; i >= 1; i-- {
+		if isBlankLine(c.Lines[i]) {
+			first = i + 2
+			break
+		}
+	}
+
+	start := 0
+	for i := len(c.Lines) - 1; i >= first; i-- {
+		line := c.Lines[i]
+		if hasGitGeneratedPrefix(line) || c.trailerSeparator(line) >= 1 {
+			start = i + 1
+		} else if !isContinuationLine(line) {
+			break
+		}
+	}
+	return start
+}
+
+// trailerSeparator returns the position of the separator of a trailer line,
+// or -1. It follows git's find_separator: a token of letters, digits and
+// hyphens, optional whitespace, then one of the trailer separators. The
+// Conventional Commits "BREAKING CHANGE" token and "<token> #<value>" form
+// are accepted as well.
+func (c *ParsedCommit) trailerSeparator(line string) int {
+	if strings.HasPrefix(line, "BREAKING CHANGE:") {
+		return len("BREAKING CHANGE")
+	}
+	whitespace := false
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		if strings.IndexByte(c.trailerSeparators, ch) >= 0 || (ch == '#' && whitespace) {
+			return i
+		}
+		if !whitespace && (isAlphanumeric(ch) || ch == '-') {
+			continue
+		}
+		if i > 0 && (ch == ' ' || ch == '\t') {
+			whitespace = true
+			continue
+		}
+		break
+	}
+	return -1
+}
+
+func hasGitGeneratedPrefix(line string) bool {
+	for _, prefix := range gitGeneratedPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAlphanumeric(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
+
+func isContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func (c *ParsedCommit) parseBody() {
+	end := len(c.Lines)
+	if c.FooterStart != 0 {
+		end = c.FooterStart - 1
+	}
+
+	var body []string
+	for i := 1; i < end; i++ {
+		if c.BodyStart == 0 && strings.TrimSpace(c.Lines[i]) == "" {
+			continue
+		}
+		if c.BodyStart == 0 {
+			c.BodyStart = i + 1
+		}
+		body = append(body, c.Lines[i])
+	}
+	c.Body = strings.TrimSpace(strings.Join(body, "\n"))
+
+	var paragraph []string
+	for _, line := range append(body, "") {
+		if strings.TrimSpace(line) == "" {
+			if len(paragraph) > 0 {
+				c.BodyParagraphs = append(c.BodyParagraphs, strings.Join(paragraph, "\n"))
+				paragraph = nil
+			}
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+}
+
+// FooterLines returns the raw lines of the footer block.
+func (c *ParsedCommit) FooterLines() []string {
+	if c.FooterStart == 0 {
+		return nil
+	}
+	return c.Lines[c.FooterStart-1:]
+}
+
+// lineOffset returns the byte offset of a 1-based line in the raw message, or
+// 0 for line 0.
+func (c *ParsedCommit) lineOffset(line int) int {
+	offset := 0
+	for i := 0; i < line-1 && i < len(c.Lines); i++ {
+		offset += len(c.Lines[i]) + 1
+	}
+	return offset
+}
+
+// position converts a byte offset in the raw message to a 1-based line and
+// column.
+func (c *ParsedCommit) position(offset int) (int, int) {
+	line := 1
+	for _, l := range c.Lines {
+		if offset <= len(l) {
+			break
+		}
+		offset -= len(l) + 1
+		line++
+	}
+	return line, offset + 1
+}
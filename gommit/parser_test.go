@@ -0,0 +1,232 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		commitType  string
+		scope       string
+		hasScope    bool
+		breaking    bool
+		description string
+		headerError string
+		errorOffset int
+	}{
+		{
+			name:        "Type and description",
+			header:      "feat: add parser",
+			commitType:  "feat",
+			description: "add parser",
+		},
+		{
+			name:        "Scope and breaking marker",
+			header:      "fix(api)!: drop v1 endpoints",
+			commitType:  "fix",
+			scope:       "api",
+			hasScope:    true,
+			breaking:    true,
+			description: "drop v1 endpoints",
+		},
+		{
+			name:        "Exclamation mark in description",
+			header:      "feat: say hello!",
+			commitType:  "feat",
+			description: "say hello!",
+		},
+		{
+			name:        "Nested parentheses in scope",
+			header:      "feat(a(b)): x",
+			commitType:  "feat",
+			scope:       "a(b",
+			hasScope:    true,
+			description: "x",
+			headerError: "unexpected '(' in scope",
+			errorOffset: 6,
+		},
+		{
+			name:        "Whitespace before separator",
+			header:      "feat : x",
+			commitType:  "feat",
+			description: "x",
+			headerError: "unexpected whitespace before ':'",
+			errorOffset: 4,
+		},
+		{
+			name:        "Missing space after colon",
+			header:      "feat:x",
+			commitType:  "feat",
+			headerError: "expected a space after ':'",
+			errorOffset: 4,
+		},
+		{
+			name:        "Unclosed scope",
+			header:      "feat(api: x",
+			commitType:  "feat",
+			scope:       "api: x",
+			hasScope:    true,
+			description: "x",
+			headerError: "unclosed scope",
+			errorOffset: 4,
+		},
+		{
+			name:        "Missing type",
+			header:      ": x",
+			description: "x",
+			headerError: "missing type",
+		},
+		{
+			name:        "No separator",
+			header:      "update readme",
+			commitType:  "update",
+			headerError: "unexpected whitespace before ':'",
+			errorOffset: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := parseCommit(tt.header, ": ")
+			if c.Type != tt.commitType || c.Scope != tt.scope || c.HasScope != tt.hasScope ||
+				c.Breaking != tt.breaking || c.Description != tt.description {
+				t.Errorf("parseCommit(%q) = type %q, scope %q (%v), breaking %v, description %q",
+					tt.header, c.Type, c.Scope, c.HasScope, c.Breaking, c.Description)
+			}
+			if c.HeaderError != tt.headerError || c.HeaderErrorOffset != tt.errorOffset {
+				t.Errorf("parseCommit(%q) header error = %q at %d, want %q at %d",
+					tt.header, c.HeaderError, c.HeaderErrorOffset, tt.headerError, tt.errorOffset)
+			}
+		})
+	}
+}
+
+func TestParseBodyAndFooters(t *testing.T) {
+	tests := []struct {
+		name        string
+		msg         string
+		body        string
+		paragraphs  []string
+		footers     []Footer
+		footerStart int
+	}{
+		{
+			name: "Body and footers",
+			msg:  "feat: add parser\n\nFirst paragraph.\n\nSecond paragraph\nwraps here.\n\nReviewed-by: Z\nRefs #133",
+			body: "First paragraph.\n\nSecond paragraph\nwraps here.",
+			paragraphs: []string{
+				"First paragraph.",
+				"Second paragraph\nwraps here.",
+			},
+			footers: []Footer{
+				{Token: "Reviewed-by", Separator: ": ", Value: "Z", Offset: 66, Line: 8},
+				{Token: "Refs", Separator: " #", Value: "133", Offset: 81, Line: 9},
+			},
+			footerStart: 8,
+		},
+		{
+			name: "Breaking change footer with a folded value",
+			msg:  "feat!: drop config\n\nBREAKING CHANGE: the config file\n  is no longer read",
+			footers: []Footer{
+				{Token: "BREAKING CHANGE", Separator: ": ", Value: "the config file is no longer read", Offset: 20, Line: 3},
+			},
+			footerStart: 3,
+		},
+		{
+			name:       "Unindented line after a footer is not a continuation",
+			msg:        "feat!: drop config\n\nBREAKING CHANGE: the config file\nis no longer read",
+			body:       "BREAKING CHANGE: the config file\nis no longer read",
+			paragraphs: []string{"BREAKING CHANGE: the config file\nis no longer read"},
+		},
+		{
+			name:       "Footers must be the last paragraph",
+			msg:        "fix: handle nil\n\nReviewed-by: Z\n\nMore details.",
+			body:       "Reviewed-by: Z\n\nMore details.",
+			paragraphs: []string{"Reviewed-by: Z", "More details."},
+		},
+		{
+			name:       "Body line looking like a footer",
+			msg:        "fix: handle nil\n\nNote: the map was never initialised.\nIt is now.",
+			body:       "Note: the map was never initialised.\nIt is now.",
+			paragraphs: []string{"Note: the map was never initialised.\nIt is now."},
+		},
+		{
+			name:       "Header paragraph is never a footer block",
+			msg:        "fix: handle nil\nRefs: #12",
+			body:       "Refs: #12",
+			paragraphs: []string{"Refs: #12"},
+		},
+		{
+			name: "Git-generated trailer with a quarter of trailers",
+			msg:  "fix: handle nil\n\nBackported from main.\nConflicts resolved by hand.\nSee the discussion.\n(cherry picked from commit 1a2b3c4)\nSigned-off-by: Jane Doe <jane@example.com>",
+			footers: []Footer{
+				{Token: "Signed-off-by", Separator: ": ", Value: "Jane Doe <jane@example.com>", Offset: 123, Line: 7},
+			},
+			footerStart: 3,
+		},
+		{
+			name:       "Less than a quarter of trailers",
+			msg:        "fix: handle nil\n\nOne.\nTwo.\nThree.\nFour.\nSigned-off-by: Jane Doe <jane@example.com>",
+			body:       "One.\nTwo.\nThree.\nFour.\nSigned-off-by: Jane Doe <jane@example.com>",
+			paragraphs: []string{"One.\nTwo.\nThree.\nFour.\nSigned-off-by: Jane Doe <jane@example.com>"},
+		},
+		{
+			name: "Unusual separators",
+			msg:  "fix: handle nil\n\nRefs:#12\nAcked-by : Z",
+			footers: []Footer{
+				{Token: "Refs", Separator: ":", Value: "#12", Offset: 17, Line: 3},
+				{Token: "Acked-by", Separator: " : ", Value: "Z", Offset: 26, Line: 4},
+			},
+			footerStart: 3,
+		},
+		{
+			name:       "Colon in body is not a footer when followed by prose",
+			msg:        "fix: handle nil\n\nThe cause: a missing check.\n\nMore details.",
+			body:       "The cause: a missing check.\n\nMore details.",
+			paragraphs: []string{"The cause: a missing check.", "More details."},
+		},
+		{
+			name:       "Header only",
+			msg:        "docs: fix typo",
+			paragraphs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := parseCommit(tt.msg, ": ")
+			if c.Body != tt.body {
+				t.Errorf("Body = %q, want %q", c.Body, tt.body)
+			}
+			if !reflect.DeepEqual(c.BodyParagraphs, tt.paragraphs) {
+				t.Errorf("BodyParagraphs = %q, want %q", c.BodyParagraphs, tt.paragraphs)
+			}
+			if !reflect.DeepEqual(c.Footers, tt.footers) {
+				t.Errorf("Footers = %+v, want %+v", c.Footers, tt.footers)
+			}
+			if c.FooterStart != tt.footerStart {
+				t.Errorf("FooterStart = %d, want %d", c.FooterStart, tt.footerStart)
+			}
+		})
+	}
+}
+
+func TestParseFootersTrailerSeparators(t *testing.T) {
+	msg := "fix: handle nil\n\nReviewed-by= Z\nRefs: #12"
+
+	if c := parseCommit(msg, ": "); len(c.Footers) != 0 {
+		t.Errorf("parseCommit() footers = %+v, want none with the default separators", c.Footers)
+	}
+
+	c := parseCommitWith(msg, ": ", ":=")
+	expected := []Footer{
+		{Token: "Reviewed-by", Separator: "= ", Value: "Z", Offset: 17, Line: 3},
+		{Token: "Refs", Separator: ": ", Value: "#12", Offset: 32, Line: 4},
+	}
+	if !reflect.DeepEqual(c.Footers, expected) {
+		t.Errorf("parseCommitWith() footers = %+v, want %+v", c.Footers, expected)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// checkReferences requires a footer such as "Refs: PROJ-123". When it is
+// missing and the current branch name carries an issue key, the violation
+// offers to append it.
+func checkReferences(commit *ParsedCommit, config Config) []Violation {
+	pattern, err := regexp.Compile(config.ReferencePattern)
+	if err != nil {
+		return []Violation{{Line: 1, Column: 1, EndColumn: 1, Message: fmt.Sprintf("Invalid reference pattern: %v", err)}}
+	}
+	for _, footer := range commit.Footers {
+		if strings.EqualFold(footer.Token, config.ReferenceToken) && pattern.MatchString(footer.Value) {
+			return nil
+		}
+	}
+
+	line := len(commit.Lines)
+	v := Violation{
+		Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1,
+		Message: fmt.Sprintf("Commit must reference an issue in a '%s: <key>' footer", config.ReferenceToken),
+	}
+	if key, branch := issueKeyFromBranch(pattern); key != "" {
+		token := config.ReferenceToken
+		v.Message += fmt.Sprintf(" (branch '%s' references %s)", branch, key)
+		v.Fix = &Fix{
+			Description: fmt.Sprintf("Append '%s: %s'", token, key),
+			Apply: func(msg string) string {
+				return appendFooter(msg, token, key)
+			},
+		}
+	}
+	return []Violation{v}
+}
+
+// issueKeyFromBranch extracts the first issue key from the current branch
+// name, e.g. PROJ-123 from feature/PROJ-123-login.
+func issueKeyFromBranch(pattern *regexp.Regexp) (string, string) {
+	branch, err := currentBranch()
+	if err != nil || branch == "" {
+		return "", ""
+	}
+	return pattern.FindString(branch), branch
+}
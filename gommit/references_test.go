@@ -0,0 +1,80 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestCheckReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		branch   string
+		expected int
+		fixed    string
+	}{
+		{
+			name:     "Reference present",
+			msg:      "feat: add login\n\nRefs: PROJ-123",
+			expected: 0,
+		},
+		{
+			name:     "Token is case-insensitive",
+			msg:      "feat: add login\n\nrefs: PROJ-123",
+			expected: 0,
+		},
+		{
+			name:     "Missing reference with key in branch",
+			msg:      "feat: add login",
+			branch:   "feature/PROJ-123-login",
+			expected: 1,
+			fixed:    "feat: add login\n\nRefs: PROJ-123",
+		},
+		{
+			name:     "Missing reference without key in branch",
+			msg:      "feat: add login\n\nRefs: #12",
+			branch:   "main",
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubGit(t, map[string]string{"symbolic-ref --short -q HEAD": tt.branch})
+
+			violations := checkReferences(parseCommit(tt.msg, ": "), defaultConfig)
+			if len(violations) != tt.expected {
+				t.Fatalf("checkReferences() = %v, want %d violations", violations, tt.expected)
+			}
+			if tt.expected == 0 {
+				return
+			}
+			if tt.fixed == "" {
+				if violations[0].Fix != nil {
+					t.Errorf("checkReferences() offered a fix without a branch key")
+				}
+				return
+			}
+			if violations[0].Fix == nil {
+				t.Fatal("checkReferences() violation has no fix")
+			}
+			if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+				t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestIssueKeyFromBranch(t *testing.T) {
+	pattern := regexp.MustCompile(defaultConfig.ReferencePattern)
+
+	stubGit(t, map[string]string{"symbolic-ref --short -q HEAD": "bugfix/ABC2-7-crash"})
+	if key, branch := issueKeyFromBranch(pattern); key != "ABC2-7" || branch != "bugfix/ABC2-7-crash" {
+		t.Errorf("issueKeyFromBranch() = %q, %q", key, branch)
+	}
+
+	stubGit(t, map[string]string{})
+	if key, _ := issueKeyFromBranch(pattern); key != "" {
+		t.Errorf("issueKeyFromBranch() on detached HEAD = %q, want empty", key)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const revertType = "revert"
+
+// revertStatementPattern matches the line "git revert" puts in the body, also
+// in its merge form "This reverts commit <sha>, reversing changes made to ...".
+var revertStatementPattern = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})[.,]`)
+
+// revertedCommits returns the SHAs named in the body of a revert commit.
+func revertedCommits(commit *ParsedCommit) []string {
+	var shas []string
+	for _, m := range revertStatementPattern.FindAllStringSubmatch(commit.Body, -1) {
+		shas = append(shas, m[1])
+	}
+	return shas
+}
+
+// checkRevertFormat validates revert commits as recommended by Conventional
+// Commits: "revert: <original header>", a "This reverts commit <sha>." body
+// and a "Refs: <sha>" footer.
+func checkRevertFormat(commit *ParsedCommit, config Config) []Violation {
+	if commit.Type != revertType {
+		return nil
+	}
+
+	var violations []Violation
+	if commit.HasDescription {
+		if original := parseCommit(commit.Description, headerSeparator(config)); original.HeaderError != "" {
+			start := commit.DescriptionOffset + 1
+			violations = append(violations, Violation{
+				Line: 1, Column: start, EndColumn: len(commit.Header) + 1,
+				Message: fmt.Sprintf("Revert header must be in format: revert: <original header> (%s)", original.HeaderError),
+			})
+		}
+	}
+
+	shas := revertedCommits(commit)
+	if len(shas) == 0 {
+		end := len(commit.Header) + 1
+		violations = append(violations, Violation{
+			Line: 1, Column: end, EndColumn: end,
+			Message: "Revert body must contain 'This reverts commit <sha>.'",
+		})
+		return violations
+	}
+
+	for _, sha := range shas {
+		if revertReferenced(commit, sha) {
+			continue
+		}
+		line := len(commit.Lines)
+		violations = append(violations, Violation{
+			Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1,
+			Message: fmt.Sprintf("Revert must reference commit %s in a 'Refs: <sha>' footer", sha),
+			Fix: &Fix{
+				Description: fmt.Sprintf("Append 'Refs: %s'", sha),
+				Apply: func(msg string) string {
+					return appendFooter(msg, "Refs", sha)
+				},
+			},
+		})
+	}
+	return violations
+}
+
+// revertReferenced reports whether a Refs footer lists sha, abbreviated or
+// not.
+func revertReferenced(commit *ParsedCommit, sha string) bool {
+	for _, footer := range commit.Footers {
+		if !strings.EqualFold(footer.Token, "Refs") {
+			continue
+		}
+		for _, ref := range strings.FieldsFunc(footer.Value, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' }) {
+			if len(ref) >= 7 && (strings.HasPrefix(sha, ref) || strings.HasPrefix(ref, sha)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkRevertCommitExists verifies that the reverted commits exist in the
+// local repository.
+func checkRevertCommitExists(commit *ParsedCommit, config Config) []Violation {
+	if commit.Type != revertType {
+		return nil
+	}
+
+	var violations []Violation
+	for _, sha := range revertedCommits(commit) {
+		if _, err := runGit("cat-file", "-e", sha+"^{commit}"); err == nil {
+			continue
+		}
+		line, column := commit.position(commit.lineOffset(commit.BodyStart) + strings.Index(commit.Body, sha))
+		violations = append(violations, Violation{
+			Line: line, Column: column, EndColumn: column + len(sha),
+			Message: fmt.Sprintf("Reverted commit %s does not exist in this repository", sha),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckRevertFormat(t *testing.T) {
+	const sha = "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b"
+
+	tests := []struct {
+		name     string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "Not a revert",
+			msg:  "feat: add parser",
+		},
+		{
+			name: "Well-formed revert",
+			msg:  "revert: feat(parser): add ast\n\nThis reverts commit " + sha + ".\n\nRefs: 1a2b3c4",
+		},
+		{
+			name:     "Original header is not conventional",
+			msg:      "revert: add ast\n\nThis reverts commit " + sha + ".\n\nRefs: " + sha,
+			expected: []string{"Revert header must be in format: revert: <original header> (unexpected whitespace before ':')"},
+		},
+		{
+			name:     "Missing revert statement",
+			msg:      "revert: feat: add ast\n\nThe parser was too slow.",
+			expected: []string{"Revert body must contain 'This reverts commit <sha>.'"},
+		},
+		{
+			name:     "Missing Refs footer",
+			msg:      "revert: feat: add ast\n\nThis reverts commit " + sha + ".",
+			expected: []string{"Revert must reference commit " + sha + " in a 'Refs: <sha>' footer"},
+			fixed:    "revert: feat: add ast\n\nThis reverts commit " + sha + ".\n\nRefs: " + sha,
+		},
+		{
+			name:     "Refs footer names another commit",
+			msg:      "revert: feat: add ast\n\nThis reverts commit " + sha + ".\n\nRefs: 9f8e7d6",
+			expected: []string{"Revert must reference commit " + sha + " in a 'Refs: <sha>' footer"},
+			fixed:    "revert: feat: add ast\n\nThis reverts commit " + sha + ".\n\nRefs: 9f8e7d6\nRefs: " + sha,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkRevertFormat(parseCommit(tt.msg, ": "), defaultConfig)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkRevertFormat() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRevertCommitExists(t *testing.T) {
+	stubGit(t, map[string]string{"cat-file -e 1a2b3c4^{commit}": ""})
+
+	msg := "revert: feat: add ast\n\nThis reverts commit 1a2b3c4.\nThis reverts commit 9f8e7d6.\n\nRefs: 1a2b3c4, 9f8e7d6"
+	violations := checkRevertCommitExists(parseCommit(msg, ": "), defaultConfig)
+	expected := []Violation{{Line: 4, Column: 21, EndColumn: 28, Message: "Reverted commit 9f8e7d6 does not exist in this repository"}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("checkRevertCommitExists() = %v, want %v", violations, expected)
+	}
+}
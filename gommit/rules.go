@@ -0,0 +1,497 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule is a single commit message check. Built-in rules register themselves
+// in init; in-house rules can do the same from their own file by calling
+// registerRule.
+type Rule interface {
+	Name() string
+	Description() string
+	DefaultSeverity() Severity
+	Check(commit *ParsedCommit, config Config) []Violation
+}
+
+type checkFunc func(commit *ParsedCommit, config Config) []Violation
+
+type basicRule struct {
+	name        string
+	description string
+	severity    Severity
+	check       checkFunc
+}
+
+func newRule(name, description string, severity Severity, check checkFunc) Rule {
+	return basicRule{name: name, description: description, severity: severity, check: check}
+}
+
+func (r basicRule) Name() string              { return r.name }
+func (r basicRule) Description() string       { return r.description }
+func (r basicRule) DefaultSeverity() Severity { return r.severity }
+
+func (r basicRule) Check(commit *ParsedCommit, config Config) []Violation {
+	if r.check == nil {
+		return nil
+	}
+	return r.check(commit, config)
+}
+
+var ruleRegistry []Rule
+
+// legacyRuleNames maps the names of replaced rules to their successor, so
+// that existing configurations keep working.
+var legacyRuleNames = map[string]string{
+	"header-lowercase": "subject-case",
+}
+
+// registerRule adds a rule to the registry. Rules are checked in registration
+// order, and registering the same name twice is a programming error.
+func registerRule(rule Rule) {
+	if lookupRule(rule.Name()) != nil {
+		panic(fmt.Sprintf("rule %q is already registered", rule.Name()))
+	}
+	ruleRegistry = append(ruleRegistry, rule)
+}
+
+func lookupRule(name string) Rule {
+	for _, rule := range ruleRegistry {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+func registeredRules() []Rule {
+	return ruleRegistry
+}
+
+var defaultRules = []Rule{
+	newRule("header-format", "Header must be in format: <type>[optional scope][!]: <description>", SeverityError, checkHeaderFormat),
+	newRule("header-max-length", "Header must not exceed the configured max length", SeverityError, checkHeaderMaxLength),
+	newRule("subject-case", "Subject must follow the configured case, except for the configured words", SeverityError, checkSubjectCase),
+	newRule("description-case", "Description must start with lowercase", SeverityError, checkDescriptionCase),
+	newRule("subject-full-stop", "Subject must not end with the configured full stop", SeverityWarning, checkSubjectFullStop),
+	newRule("subject-min-length", "Subject must not be shorter than the configured min length", SeverityError, checkSubjectMinLength),
+	newRule("body-leading-blank", "Body must be separated from the header by a blank line", SeverityWarning, checkBodyLeadingBlank),
+	newRule("body-line-max-length", "Body lines must not exceed the configured max length", SeverityError, checkBodyLineMaxLength),
+	newRule("body-min-length", "Body must not be shorter than the configured min length", SeverityError, checkBodyMinLength),
+	newRule("body-max-length", "Body must not exceed the configured max length", SeverityError, checkBodyMaxLength),
+	newRule("body-empty", "Body must not be empty for the configured types", SeverityOff, checkBodyEmpty),
+	newRule("footer-leading-blank", "Footers must be separated from the body by a blank line", SeverityWarning, checkFooterLeadingBlank),
+	newRule("footer-max-line-length", "Footer lines must not exceed the configured max length", SeverityError, checkFooterMaxLineLength),
+	newRule("footer-token", "Footer tokens must not be misspellings of well-known tokens", SeverityWarning, checkFooterToken),
+	newRule("footer-enum", "Footer tokens must be declared when footers.reject_unknown is set", SeverityError, checkFooterEnum),
+	newRule("footer-value", "Footer values must match the pattern declared for their token", SeverityError, checkFooterValue),
+	newRule("footer-required", "Footers declared as required must be present", SeverityError, checkFooterRequired),
+	newRule("footer-unique", "Footers declared as unique must not be repeated", SeverityError, checkFooterUnique),
+	newRule("footer-format", "Footer must be in format: <token>: <value>", SeverityError, checkFooterFormat),
+	newRule("breaking-change", "Breaking changes must be indicated in footer", SeverityWarning, checkBreakingChange),
+	newRule("breaking-change-marker", "Breaking change footers require '!' in the header", SeverityWarning, checkBreakingChangeMarker),
+	newRule("breaking-change-empty", "Breaking change footers must describe the change", SeverityError, checkBreakingChangeEmpty),
+	newRule("breaking-change-token", "Breaking change token must be uppercase and follow breaking_change_token", SeverityWarning, checkBreakingChangeToken),
+	newRule(AUTO_BREAKING_CHANGE, "Automatically add BREAKING CHANGE to footer when '!' is present in header", SeverityError, nil),
+	newRule("type-alias", "Type must not be an alias of an allowed type", SeverityWarning, checkTypeAlias),
+	newRule("type-enum", "Type must be one of the allowed types", SeverityError, checkTypeEnum),
+	newRule("type-case", "Type must be in lowercase", SeverityError, checkTypeCase),
+	newRule("type-empty", "Type must not be empty", SeverityError, checkTypeEmpty),
+	newRule("scope-case", "Scope must be in lowercase", SeverityError, checkScopeCase),
+	newRule("scope-alias", "Scope must not be an alias of an allowed scope", SeverityWarning, checkScopeAlias),
+	newRule("scope-enum", "Each scope must be declared in the allowed scopes tree", SeverityError, checkScopeEnum),
+	newRule("scope-empty", "Scope must not be empty for the configured types", SeverityOff, checkScopeEmpty),
+	newRule("subject-empty", "Subject must not be empty", SeverityError, checkSubjectEmpty),
+	newRule("revert-format", "Revert commits must name the original header and the reverted commit", SeverityWarning, checkRevertFormat),
+	newRule("revert-commit-exists", "Reverted commits must exist in the local repository", SeverityOff, checkRevertCommitExists),
+	newRule("signed-off-by", "Commit must be signed off by the committer (Developer Certificate of Origin)", SeverityOff, checkSignedOffBy),
+	newRule("change-id", "Commit must have a Gerrit Change-Id footer, generated when missing", SeverityOff, checkChangeID),
+	newRule("references-empty", "Footer must reference an issue, taken from the branch name when possible", SeverityOff, checkReferences),
+}
+
+func init() {
+	for _, rule := range defaultRules {
+		registerRule(rule)
+	}
+}
+
+func headerViolation(commit *ParsedCommit, message string) Violation {
+	return Violation{Line: 1, Column: 1, EndColumn: len(commit.Header) + 1, Message: message}
+}
+
+func checkHeaderFormat(commit *ParsedCommit, config Config) []Violation {
+	message := "Header must be in format: <type>[optional scope][!]: <description>"
+	if commit.HeaderError != "" {
+		v := headerViolation(commit, message+" ("+commit.HeaderError+")")
+		v.Column = commit.HeaderErrorOffset + 1
+		return []Violation{v}
+	}
+	pattern, err := buildHeaderPattern(config)
+	if err != nil {
+		return []Violation{headerViolation(commit, err.Error())}
+	}
+	if pattern.MatchString(commit.Header) {
+		return nil
+	}
+	return []Violation{headerViolation(commit, message)}
+}
+
+func checkHeaderMaxLength(commit *ParsedCommit, config Config) []Violation {
+	if textLength(commit.Header, config.LengthUnit) <= config.HeaderMaxLength {
+		return nil
+	}
+	return []Violation{{
+		Line: 1, Column: lengthOffset(commit.Header, config.HeaderMaxLength, config.LengthUnit) + 1, EndColumn: len(commit.Header) + 1,
+		Message: fmt.Sprintf("Header must not exceed %d characters", config.HeaderMaxLength),
+	}}
+}
+
+func checkTypeEnum(commit *ParsedCommit, config Config) []Violation {
+	_, aliased := config.TypeAliases[commit.Type]
+	if contains(config.AllowedTypes, commit.Type) || isAliased(config, "type-alias", aliased) {
+		return nil
+	}
+	allowed := strings.Join(config.AllowedTypes, ", ")
+	v := Violation{
+		Line: 1, Column: 1, EndColumn: len(commit.Type) + 1,
+		Message: fmt.Sprintf("Type '%s' is not allowed. Allowed types are: %s", commit.Type, allowed),
+	}
+	if suggestion, ok := closestMatch(commit.Type, config.AllowedTypes); ok && commit.Type != "" {
+		v.Message = fmt.Sprintf("Type '%s' is not allowed — did you mean '%s'? Allowed types are: %s", commit.Type, suggestion, allowed)
+		fixed := suggestion + commit.Header[len(commit.Type):]
+		v.Fix = &Fix{
+			Description: fmt.Sprintf("Change type '%s' to '%s'", commit.Type, suggestion),
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		}
+	}
+	return []Violation{v}
+}
+
+func checkTypeCase(commit *ParsedCommit, config Config) []Violation {
+	commitType := commit.Type
+	lower := strings.ToLower(commitType)
+	if commitType == lower {
+		return nil
+	}
+	return []Violation{{
+		Line: 1, Column: 1, EndColumn: len(commitType) + 1,
+		Message: "Type must be in lowercase",
+		Fix: &Fix{
+			Description: fmt.Sprintf("Change type to '%s'", lower),
+			Apply: func(msg string) string {
+				return strings.Replace(msg, commitType, lower, 1)
+			},
+		},
+	}}
+}
+
+func checkTypeEmpty(commit *ParsedCommit, config Config) []Violation {
+	if commit.Type != "" {
+		return nil
+	}
+	return []Violation{{Line: 1, Column: 1, EndColumn: 1, Message: "Type must not be empty"}}
+}
+
+func checkScopeCase(commit *ParsedCommit, config Config) []Violation {
+	scope := commit.Scope
+	lower := strings.ToLower(scope)
+	if !commit.HasScope || scope == lower {
+		return nil
+	}
+	start := commit.ScopeOffset + 1
+	return []Violation{{
+		Line: 1, Column: start, EndColumn: start + len(scope),
+		Message: "Scope must be in lowercase",
+		Fix: &Fix{
+			Description: fmt.Sprintf("Change scope to '%s'", lower),
+			Apply: func(msg string) string {
+				return strings.Replace(msg, "("+scope+")", "("+lower+")", 1)
+			},
+		},
+	}}
+}
+
+// checkScopeEnum validates each element of a multi-scope against the
+// allowed_scopes tree.
+func checkScopeEnum(commit *ParsedCommit, config Config) []Violation {
+	if len(config.AllowedScopes) == 0 || !commit.HasScope {
+		return nil
+	}
+	allowed := scopePaths(config.AllowedScopes)
+
+	var violations []Violation
+	for _, element := range splitScopes(commit.Scope, commit.ScopeOffset) {
+		_, aliased := scopeAlias(config.ScopeAliases, element.Value)
+		if isScopeAllowed(config.AllowedScopes, element.Value) || isAliased(config, "scope-alias", aliased) {
+			continue
+		}
+		start := element.Offset + 1
+		v := Violation{
+			Line: 1, Column: start, EndColumn: start + len(element.Value),
+			Message: fmt.Sprintf("Scope '%s' is not allowed. Allowed scopes are: %s", element.Value, strings.Join(allowed, ", ")),
+		}
+		if suggestion, ok := closestMatch(element.Value, allowed); ok {
+			v.Message = fmt.Sprintf("Scope '%s' is not allowed — did you mean '%s'? Allowed scopes are: %s", element.Value, suggestion, strings.Join(allowed, ", "))
+			fixed := commit.Header[:element.Offset] + suggestion + commit.Header[element.Offset+len(element.Value):]
+			v.Fix = &Fix{
+				Description: fmt.Sprintf("Change scope '%s' to '%s'", element.Value, suggestion),
+				Apply: func(msg string) string {
+					return replaceLine(msg, 1, fixed)
+				},
+			}
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// checkScopeEmpty requires a scope for the types in scope_required_types, or
+// for every type when that list is empty.
+func checkScopeEmpty(commit *ParsedCommit, config Config) []Violation {
+	if commit.HasScope && strings.TrimSpace(commit.Scope) != "" {
+		return nil
+	}
+	if len(config.ScopeRequiredTypes) > 0 && !contains(config.ScopeRequiredTypes, commit.Type) {
+		return nil
+	}
+	end := len(commit.Type) + 1
+	message := "Scope must not be empty"
+	if len(config.AllowedScopes) > 0 {
+		message += ". Allowed scopes are: " + strings.Join(scopePaths(config.AllowedScopes), ", ")
+	}
+	return []Violation{{Line: 1, Column: end, EndColumn: end, Message: message}}
+}
+
+func checkSubjectEmpty(commit *ParsedCommit, config Config) []Violation {
+	if commit.HasDescription {
+		return nil
+	}
+	end := len(commit.Header) + 1
+	return []Violation{{Line: 1, Column: end, EndColumn: end, Message: "Subject must not be empty"}}
+}
+
+// checkDescriptionCase is skipped in sentence-case mode, which requires the
+// opposite, and for words exempted through subject_case_exceptions.
+// checkDescriptionCase leaves the first word to subject-case whenever that
+// rule checks it, so a capitalised description is reported only once.
+func checkDescriptionCase(commit *ParsedCommit, config Config) []Violation {
+	if config.SubjectCase != subjectCaseAny && isRuleEnabled(config, "subject-case") {
+		return nil
+	}
+	if words := subjectWords(commit); len(words) > 0 && isExemptWord(words[0].Value, config.SubjectCaseExceptions) {
+		return nil
+	}
+	first, size := utf8.DecodeRuneInString(commit.Description)
+	if size == 0 || !(unicode.IsUpper(first) || unicode.IsTitle(first)) {
+		return nil
+	}
+	start := commit.DescriptionOffset + 1
+	fixed := commit.Header[:commit.DescriptionOffset] + string(unicode.ToLower(first)) + commit.Description[size:]
+	return []Violation{{
+		Line: 1, Column: start, EndColumn: start + size,
+		Message: "Description must start with lowercase",
+		Fix: &Fix{
+			Description: "Lowercase the first letter of the description",
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		},
+	}}
+}
+
+// checkBodyLeadingBlank leaves trailers that directly follow the header to
+// footer-leading-blank.
+func checkBodyLeadingBlank(commit *ParsedCommit, config Config) []Violation {
+	if commit.BodyStart != 2 || commit.strayTrailerStart() == 2 {
+		return nil
+	}
+	return []Violation{blankLineViolation(commit.BodyStart, "Body must be separated from the header by a blank line")}
+}
+
+// checkFooterLeadingBlank reports trailers that directly follow the header or
+// the last line of the body, which git does not recognise as trailers.
+func checkFooterLeadingBlank(commit *ParsedCommit, config Config) []Violation {
+	line := commit.strayTrailerStart()
+	if line == 0 {
+		return nil
+	}
+	return []Violation{blankLineViolation(line, "Footers must be separated from the body by a blank line")}
+}
+
+func blankLineViolation(line int, message string) Violation {
+	return Violation{
+		Line: line, Column: 1, EndColumn: 1,
+		Message: message,
+		Fix: &Fix{
+			Description: fmt.Sprintf("Insert a blank line before line %d", line),
+			Apply: func(msg string) string {
+				return insertLine(msg, line, "")
+			},
+		},
+	}
+}
+
+func checkSubjectFullStop(commit *ParsedCommit, config Config) []Violation {
+	fullStop := config.SubjectFullStop
+	if fullStop == "" || !strings.HasSuffix(commit.Description, fullStop) {
+		return nil
+	}
+	end := len(commit.Header) + 1
+	fixed := strings.TrimSuffix(commit.Header, fullStop)
+	return []Violation{{
+		Line: 1, Column: end - len(fullStop), EndColumn: end,
+		Message: fmt.Sprintf("Subject must not end with '%s'", fullStop),
+		Fix: &Fix{
+			Description: fmt.Sprintf("Remove the trailing '%s'", fullStop),
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		},
+	}}
+}
+
+func checkSubjectMinLength(commit *ParsedCommit, config Config) []Violation {
+	if !commit.HasDescription || textLength(commit.Description, config.LengthUnit) >= config.SubjectMinLength {
+		return nil
+	}
+	start := commit.DescriptionOffset + 1
+	return []Violation{{
+		Line: 1, Column: start, EndColumn: len(commit.Header) + 1,
+		Message: fmt.Sprintf("Subject must be at least %d characters", config.SubjectMinLength),
+	}}
+}
+
+// bodyLines returns the lines between the header and the footer block.
+func bodyLines(commit *ParsedCommit) []string {
+	end := len(commit.Lines)
+	if commit.FooterStart != 0 {
+		end = commit.FooterStart - 1
+	}
+	return commit.Lines[1:end]
+}
+
+// checkBodyLineMaxLength offers to rewrap the prose of the body, and skips
+// the code and URL lines exempted by the configuration.
+func checkBodyLineMaxLength(commit *ParsedCommit, config Config) []Violation {
+	lines := bodyLines(commit)
+	blocks := bodyBlocks(lines)
+
+	var violations []Violation
+	for _, v := range lineMaxLengthViolations(lines, 2, config.BodyLineMaxLength, config.LengthUnit, "Body") {
+		block := blockAt(blocks, v.Line-2)
+		if isLineLengthExempt(lines[v.Line-2], block, config) {
+			continue
+		}
+		if block.wrappable() {
+			v.Fix = &Fix{
+				Description: fmt.Sprintf("Rewrap the body to %d characters", config.BodyLineMaxLength),
+				Apply: func(msg string) string {
+					return rewrapBody(parseCommit(msg, headerSeparator(config)), config)
+				},
+			}
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func checkFooterMaxLineLength(commit *ParsedCommit, config Config) []Violation {
+	return lineMaxLengthViolations(commit.FooterLines(), commit.FooterStart, config.FooterMaxLineLength, config.LengthUnit, "Footer")
+}
+
+// lineMaxLengthViolations reports the lines longer than limit, first being the
+// 1-based line number of lines[0].
+func lineMaxLengthViolations(lines []string, first, limit int, unit, kind string) []Violation {
+	var violations []Violation
+	for i, line := range lines {
+		if textLength(line, unit) > limit {
+			violations = append(violations, Violation{
+				Line: first + i, Column: lengthOffset(line, limit, unit) + 1, EndColumn: len(line) + 1,
+				Message: fmt.Sprintf("%s line %d exceeds %d characters", kind, first+i, limit),
+			})
+		}
+	}
+	return violations
+}
+
+// checkBodyMinLength does not report an empty body, which is the job of
+// body-empty.
+func checkBodyMinLength(commit *ParsedCommit, config Config) []Violation {
+	if commit.Body == "" || textLength(commit.Body, config.LengthUnit) >= config.BodyMinLength {
+		return nil
+	}
+	return []Violation{bodyViolation(commit, fmt.Sprintf("Body must be at least %d characters", config.BodyMinLength))}
+}
+
+// checkBodyMaxLength is skipped when body_max_length is 0.
+func checkBodyMaxLength(commit *ParsedCommit, config Config) []Violation {
+	if config.BodyMaxLength == 0 || textLength(commit.Body, config.LengthUnit) <= config.BodyMaxLength {
+		return nil
+	}
+	return []Violation{bodyViolation(commit, fmt.Sprintf("Body must not exceed %d characters", config.BodyMaxLength))}
+}
+
+// checkBodyEmpty requires a body for the types in body_required_types, or for
+// every type when that list is empty.
+func checkBodyEmpty(commit *ParsedCommit, config Config) []Violation {
+	if commit.Body != "" {
+		return nil
+	}
+	if len(config.BodyRequiredTypes) > 0 && !contains(config.BodyRequiredTypes, commit.Type) {
+		return nil
+	}
+	message := "Body must not be empty"
+	if commit.Type != "" {
+		message = fmt.Sprintf("Body must not be empty for '%s' commits", commit.Type)
+	}
+	end := len(commit.Header) + 1
+	return []Violation{{Line: 1, Column: end, EndColumn: end, Message: message}}
+}
+
+func bodyViolation(commit *ParsedCommit, message string) Violation {
+	line := commit.BodyStart
+	return Violation{Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1, Message: message}
+}
+
+// checkFooterFormat reports footers with an empty value or an unusual
+// separator, such as "Refs:#12" or "Refs : #12", which git accepts as
+// trailers.
+func checkFooterFormat(commit *ParsedCommit, config Config) []Violation {
+	var violations []Violation
+	for _, footer := range commit.Footers {
+		// An empty breaking change is reported by breaking-change-empty.
+		if footer.Value == "" && isBreakingChangeToken(footer.Token) {
+			continue
+		}
+		if footer.Value != "" && isCanonicalSeparator(footer.Separator, commit.trailerSeparators) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Line: footer.Line, Column: 1, EndColumn: len(commit.Lines[footer.Line-1]) + 1,
+			Message: fmt.Sprintf("Footer line %d must be in format: <token>: <value>", footer.Line),
+		})
+	}
+	return violations
+}
+
+func isCanonicalSeparator(separator, trailerSeparators string) bool {
+	if separator == " #" {
+		return true
+	}
+	return len(separator) == 2 && separator[1] == ' ' && strings.IndexByte(trailerSeparators, separator[0]) >= 0
+}
+
+func checkBreakingChange(commit *ParsedCommit, config Config) []Violation {
+	if !commit.Breaking || len(breakingChangeFooters(commit)) > 0 {
+		return nil
+	}
+	return []Violation{{
+		Line: 1, Column: commit.BreakingOffset + 1, EndColumn: commit.BreakingOffset + 2,
+		Message: "Breaking change must be described in a BREAKING CHANGE footer",
+	}}
+}
@@ -0,0 +1,521 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRulesRegistered(t *testing.T) {
+	for _, rule := range defaultRules {
+		if lookupRule(rule.Name()) == nil {
+			t.Errorf("rule %q is not registered", rule.Name())
+		}
+		if rule.Description() == "" {
+			t.Errorf("rule %q has no description", rule.Name())
+		}
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	saved := ruleRegistry
+	defer func() { ruleRegistry = saved }()
+	ruleRegistry = append([]Rule(nil), saved...)
+
+	noWip := newRule("no-wip", "Description must not contain wip", SeverityWarning, func(commit *ParsedCommit, config Config) []Violation {
+		if !strings.Contains(commit.Description, "wip") {
+			return nil
+		}
+		return []Violation{{Line: 1, Column: 1, EndColumn: 1, Message: "Description contains wip"}}
+	})
+	registerRule(noWip)
+
+	violations := validateCommitMsg("feat: wip on parser", defaultConfig)
+	expected := []Violation{{Rule: "no-wip", Severity: SeverityWarning, Line: 1, Column: 1, EndColumn: 1, Message: "Description contains wip"}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("validateCommitMsg() = %v, want %v", violations, expected)
+	}
+
+	config := defaultConfig
+	config.Rules = map[string]Severity{"no-wip": SeverityOff}
+	if violations := validateCommitMsg("feat: wip on parser", config); len(violations) != 0 {
+		t.Errorf("validateCommitMsg() with rule off = %v, want none", violations)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registerRule() did not panic on a duplicate name")
+		}
+	}()
+	registerRule(noWip)
+}
+
+func TestCheckScopeCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected int
+	}{
+		{
+			name:     "Lowercase scope",
+			msg:      "feat(api): add endpoint",
+			expected: 0,
+		},
+		{
+			name:     "Uppercase scope",
+			msg:      "feat(API): add endpoint",
+			expected: 1,
+		},
+		{
+			name:     "No scope",
+			msg:      "feat: add endpoint",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkScopeCase(parseCommit(tt.msg, ": "), defaultConfig)
+			if len(violations) != tt.expected {
+				t.Errorf("checkScopeCase() = %v, want %d violations", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckHeaderFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected []string
+	}{
+		{
+			name:     "Valid header",
+			msg:      "feat(parser): add ast",
+			expected: nil,
+		},
+		{
+			name:     "Nested scope",
+			msg:      "feat(a(b)): x",
+			expected: []string{"Header must be in format: <type>[optional scope][!]: <description> (unexpected '(' in scope)"},
+		},
+		{
+			name:     "Space before colon",
+			msg:      "feat : x",
+			expected: []string{"Header must be in format: <type>[optional scope][!]: <description> (unexpected whitespace before ':')"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []string
+			for _, v := range checkHeaderFormat(parseCommit(tt.msg, ": "), defaultConfig) {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkHeaderFormat() = %v, want %v", messages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckScopeEnum(t *testing.T) {
+	config := defaultConfig
+	config.AllowedScopes = []ScopeNode{
+		{Name: "api", Children: []ScopeNode{{Name: "auth"}, {Name: "users"}}},
+		{Name: "cli"},
+		{Name: "docs"},
+	}
+
+	tests := []struct {
+		name     string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "Allowed scope",
+			msg:  "feat(api): add endpoint",
+		},
+		{
+			name: "No scope",
+			msg:  "feat: add endpoint",
+		},
+		{
+			name:     "Typo in scope",
+			msg:      "feat(apii): add endpoint",
+			expected: []string{"Scope 'apii' is not allowed — did you mean 'api'? Allowed scopes are: api, api/auth, api/users, cli, docs"},
+			fixed:    "feat(api): add endpoint",
+		},
+		{
+			name:     "Unknown scope",
+			msg:      "feat(database): add index",
+			expected: []string{"Scope 'database' is not allowed. Allowed scopes are: api, api/auth, api/users, cli, docs"},
+		},
+		{
+			name: "Multiple allowed scopes",
+			msg:  "feat(api,cli): add flag",
+		},
+		{
+			name: "Hierarchical scope",
+			msg:  "fix(api/auth, docs): refresh tokens",
+		},
+		{
+			name:     "Child declared under another parent",
+			msg:      "fix(cli/auth): refresh tokens",
+			expected: []string{"Scope 'cli/auth' is not allowed — did you mean 'api/auth'? Allowed scopes are: api, api/auth, api/users, cli, docs"},
+			fixed:    "fix(api/auth): refresh tokens",
+		},
+		{
+			name:     "One bad element in a multi-scope",
+			msg:      "feat(cli, dcs): add flag",
+			expected: []string{"Scope 'dcs' is not allowed — did you mean 'docs'? Allowed scopes are: api, api/auth, api/users, cli, docs"},
+			fixed:    "feat(cli, docs): add flag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkScopeEnum(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkScopeEnum() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if violations[0].Fix == nil {
+					t.Fatal("checkScopeEnum() violation has no fix")
+				}
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+
+	if violations := checkScopeEnum(parseCommit("feat(anything): x", ": "), defaultConfig); len(violations) != 0 {
+		t.Errorf("checkScopeEnum() without allowed scopes = %v, want none", violations)
+	}
+}
+
+func TestCheckScopeEmpty(t *testing.T) {
+	tests := []struct {
+		name          string
+		msg           string
+		requiredTypes []string
+		expected      int
+	}{
+		{
+			name:     "Scope present",
+			msg:      "feat(api): add endpoint",
+			expected: 0,
+		},
+		{
+			name:     "Scope required for every type",
+			msg:      "docs: fix typo",
+			expected: 1,
+		},
+		{
+			name:          "Scope required for another type",
+			msg:           "docs: fix typo",
+			requiredTypes: []string{"feat", "fix"},
+			expected:      0,
+		},
+		{
+			name:          "Scope required for this type",
+			msg:           "fix: handle nil",
+			requiredTypes: []string{"feat", "fix"},
+			expected:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig
+			config.ScopeRequiredTypes = tt.requiredTypes
+			if violations := checkScopeEmpty(parseCommit(tt.msg, ": "), config); len(violations) != tt.expected {
+				t.Errorf("checkScopeEmpty() = %v, want %d violations", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckLeadingBlank(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		check    checkFunc
+		expected []Violation
+		fixed    string
+	}{
+		{
+			name:  "Body separated from the header",
+			msg:   "feat: add parser\n\nBuild an AST.",
+			check: checkBodyLeadingBlank,
+		},
+		{
+			name:     "Body directly after the header",
+			msg:      "feat: add parser\nBuild an AST.",
+			check:    checkBodyLeadingBlank,
+			expected: []Violation{{Line: 2, Column: 1, EndColumn: 1, Message: "Body must be separated from the header by a blank line"}},
+			fixed:    "feat: add parser\n\nBuild an AST.",
+		},
+		{
+			name:  "Header only",
+			msg:   "feat: add parser",
+			check: checkBodyLeadingBlank,
+		},
+		{
+			name:  "Footers separated from the body",
+			msg:   "feat: add parser\n\nBuild an AST.\n\nRefs: #12",
+			check: checkFooterLeadingBlank,
+		},
+		{
+			name:     "Footers directly after the body",
+			msg:      "feat: add parser\n\nBuild an AST.\nRefs: #12\nReviewed-by: Z",
+			check:    checkFooterLeadingBlank,
+			expected: []Violation{{Line: 4, Column: 1, EndColumn: 1, Message: "Footers must be separated from the body by a blank line"}},
+			fixed:    "feat: add parser\n\nBuild an AST.\n\nRefs: #12\nReviewed-by: Z",
+		},
+		{
+			name:     "Footers directly after the header",
+			msg:      "feat: add parser\nRefs: #12",
+			check:    checkFooterLeadingBlank,
+			expected: []Violation{{Line: 2, Column: 1, EndColumn: 1, Message: "Footers must be separated from the body by a blank line"}},
+			fixed:    "feat: add parser\n\nRefs: #12",
+		},
+		{
+			name:  "Footers after the header and a blank line",
+			msg:   "feat: add parser\n\nRefs: #12",
+			check: checkFooterLeadingBlank,
+		},
+		{
+			name:  "Body line looking like a footer",
+			msg:   "feat: add parser\n\nBuild an AST.\n\nNote: the lexer is unchanged.\nIt only tokenises.",
+			check: checkFooterLeadingBlank,
+		},
+		{
+			name:     "Footers with a folded value directly after the body",
+			msg:      "feat: add parser\n\nBuild an AST.\nBREAKING CHANGE: the old parser\n  is removed",
+			check:    checkFooterLeadingBlank,
+			expected: []Violation{{Line: 4, Column: 1, EndColumn: 1, Message: "Footers must be separated from the body by a blank line"}},
+			fixed:    "feat: add parser\n\nBuild an AST.\n\nBREAKING CHANGE: the old parser\n  is removed",
+		},
+		{
+			name:  "Footers directly after the header reported once",
+			msg:   "feat: add parser\nRefs: #12",
+			check: checkBodyLeadingBlank,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := tt.check(parseCommit(tt.msg, ": "), defaultConfig)
+			var fixed string
+			for i := range violations {
+				fixed = violations[i].Fix.Apply(tt.msg)
+				violations[i].Fix = nil
+			}
+			if !reflect.DeepEqual(violations, tt.expected) {
+				t.Errorf("check() = %v, want %v", violations, tt.expected)
+			}
+			if fixed != tt.fixed {
+				t.Errorf("Fix.Apply() = %q, want %q", fixed, tt.fixed)
+			}
+		})
+	}
+}
+
+func TestCheckContentRules(t *testing.T) {
+	longFooter := "Reviewed-by: " + strings.Repeat("x", 90)
+
+	tests := []struct {
+		name     string
+		msg      string
+		check    checkFunc
+		config   func(*Config)
+		expected []string
+		fixed    string
+	}{
+		{
+			name:  "Subject without full stop",
+			msg:   "feat: add parser",
+			check: checkSubjectFullStop,
+		},
+		{
+			name:     "Subject with full stop",
+			msg:      "feat: add parser.",
+			check:    checkSubjectFullStop,
+			expected: []string{"Subject must not end with '.'"},
+			fixed:    "feat: add parser",
+		},
+		{
+			name:     "Only the full stop is removed",
+			msg:      "feat: add etc...",
+			check:    checkSubjectFullStop,
+			expected: []string{"Subject must not end with '.'"},
+			fixed:    "feat: add etc..",
+		},
+		{
+			name:     "Multi-character full stop",
+			msg:      "feat: add parser!?!?",
+			check:    checkSubjectFullStop,
+			config:   func(c *Config) { c.SubjectFullStop = "!?" },
+			expected: []string{"Subject must not end with '!?'"},
+			fixed:    "feat: add parser!?",
+		},
+		{
+			name:   "Subject long enough",
+			msg:    "feat: add parser",
+			check:  checkSubjectMinLength,
+			config: func(c *Config) { c.SubjectMinLength = 10 },
+		},
+		{
+			name:     "Subject too short",
+			msg:      "fix: typo",
+			check:    checkSubjectMinLength,
+			config:   func(c *Config) { c.SubjectMinLength = 10 },
+			expected: []string{"Subject must be at least 10 characters"},
+		},
+		{
+			name:     "Body too short",
+			msg:      "feat: add parser\n\nAST.",
+			check:    checkBodyMinLength,
+			config:   func(c *Config) { c.BodyMinLength = 20 },
+			expected: []string{"Body must be at least 20 characters"},
+		},
+		{
+			name:   "Empty body is left to body-empty",
+			msg:    "feat: add parser",
+			check:  checkBodyMinLength,
+			config: func(c *Config) { c.BodyMinLength = 20 },
+		},
+		{
+			name:     "Body too long",
+			msg:      "feat: add parser\n\nBuild an AST.\n\nThen walk it.",
+			check:    checkBodyMaxLength,
+			config:   func(c *Config) { c.BodyMaxLength = 20 },
+			expected: []string{"Body must not exceed 20 characters"},
+		},
+		{
+			name:  "Body max length disabled",
+			msg:   "feat: add parser\n\nBuild an AST.\n\nThen walk it.",
+			check: checkBodyMaxLength,
+		},
+		{
+			name:     "Body required for every type",
+			msg:      "docs: fix typo",
+			check:    checkBodyEmpty,
+			expected: []string{"Body must not be empty for 'docs' commits"},
+		},
+		{
+			name:   "Body required for another type",
+			msg:    "docs: fix typo",
+			check:  checkBodyEmpty,
+			config: func(c *Config) { c.BodyRequiredTypes = []string{"feat", "fix"} },
+		},
+		{
+			name:     "Body required for this type",
+			msg:      "fix: handle nil\n\nRefs: #12",
+			check:    checkBodyEmpty,
+			config:   func(c *Config) { c.BodyRequiredTypes = []string{"feat", "fix"} },
+			expected: []string{"Body must not be empty for 'fix' commits"},
+		},
+		{
+			name:  "Long footer line within the footer limit",
+			msg:   "feat: add parser\n\n" + longFooter,
+			check: checkBodyLineMaxLength,
+		},
+		{
+			name:     "Long footer line",
+			msg:      "feat: add parser\n\n" + longFooter + "y",
+			check:    checkFooterMaxLineLength,
+			expected: []string{"Footer line 3 exceeds 100 characters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig
+			if tt.config != nil {
+				tt.config(&config)
+			}
+			violations := tt.check(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("check() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTypeEnum(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "Allowed type",
+			msg:  "feat: add parser",
+		},
+		{
+			name:     "Longer spelling of an allowed type",
+			msg:      "feature: add parser",
+			expected: []string{"Type 'feature' is not allowed — did you mean 'feat'? Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
+			fixed:    "feat: add parser",
+		},
+		{
+			name:     "Typo",
+			msg:      "refactr(api): extract parser",
+			expected: []string{"Type 'refactr' is not allowed — did you mean 'refactor'? Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
+			fixed:    "refactor(api): extract parser",
+		},
+		{
+			name:     "Unknown type",
+			msg:      "wip: add parser",
+			expected: []string{"Type 'wip' is not allowed. Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
+		},
+		{
+			name:     "Short unrelated type",
+			msg:      "foo: add parser",
+			expected: []string{"Type 'foo' is not allowed. Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
+		},
+		{
+			name:     "Short type two edits away",
+			msg:      "just: add parser",
+			expected: []string{"Type 'just' is not allowed. Allowed types are: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := checkTypeEnum(parseCommit(tt.msg, ": "), defaultConfig)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkTypeEnum() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			} else if len(violations) > 0 && violations[0].Fix != nil {
+				t.Errorf("checkTypeEnum() offered a fix without a clear suggestion")
+			}
+		})
+	}
+}
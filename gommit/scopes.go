@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	scopeListSeparator = ","
+	scopePathSeparator = "/"
+)
+
+// ScopeNode is an entry of the allowed_scopes tree. In YAML a leaf is a plain
+// string and a node with children is a single-key mapping:
+//
+//	allowed_scopes:
+//	  - cli
+//	  - api:
+//	      - auth
+//	      - users
+type ScopeNode struct {
+	Name     string
+	Children []ScopeNode
+}
+
+func (n *ScopeNode) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		n.Name = value.Value
+		return nil
+	case yaml.MappingNode:
+		if len(value.Content) != 2 {
+			return fmt.Errorf("line %d: scope must have exactly one name", value.Line)
+		}
+		n.Name = value.Content[0].Value
+		return value.Content[1].Decode(&n.Children)
+	default:
+		return fmt.Errorf("line %d: scope must be a string or a mapping", value.Line)
+	}
+}
+
+// scopePaths flattens a scope tree into slash-delimited paths, parents first.
+func scopePaths(nodes []ScopeNode) []string {
+	var paths []string
+	for _, node := range nodes {
+		paths = append(paths, node.Name)
+		for _, child := range scopePaths(node.Children) {
+			paths = append(paths, node.Name+scopePathSeparator+child)
+		}
+	}
+	return paths
+}
+
+// isScopeAllowed reports whether a hierarchical scope such as "api/auth" is
+// declared in the tree, each segment being a child of the previous one.
+func isScopeAllowed(nodes []ScopeNode, scope string) bool {
+	for _, segment := range strings.Split(scope, scopePathSeparator) {
+		found := false
+		for _, node := range nodes {
+			if node.Name == segment {
+				nodes = node.Children
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// scopeElement is one comma-separated element of a multi-scope, with its byte
+// offset in the header.
+type scopeElement struct {
+	Value  string
+	Offset int
+}
+
+func splitScopes(scope string, offset int) []scopeElement {
+	var elements []scopeElement
+	for _, part := range strings.Split(scope, scopeListSeparator) {
+		trimmed := strings.TrimLeft(part, " ")
+		elements = append(elements, scopeElement{
+			Value:  strings.TrimRight(trimmed, " "),
+			Offset: offset + len(part) - len(trimmed),
+		})
+		offset += len(part) + len(scopeListSeparator)
+	}
+	return elements
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestScopeNodeUnmarshalYAML(t *testing.T) {
+	var config Config
+	err := yaml.Unmarshal([]byte(`
+allowed_scopes:
+  - cli
+  - api:
+      - auth
+      - users:
+          - admin
+`), &config)
+	if err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	expected := []ScopeNode{
+		{Name: "cli"},
+		{Name: "api", Children: []ScopeNode{
+			{Name: "auth"},
+			{Name: "users", Children: []ScopeNode{{Name: "admin"}}},
+		}},
+	}
+	if !reflect.DeepEqual(config.AllowedScopes, expected) {
+		t.Errorf("AllowedScopes = %+v, want %+v", config.AllowedScopes, expected)
+	}
+
+	if paths := scopePaths(config.AllowedScopes); !reflect.DeepEqual(paths, []string{"cli", "api", "api/auth", "api/users", "api/users/admin"}) {
+		t.Errorf("scopePaths() = %v", paths)
+	}
+
+	if err := yaml.Unmarshal([]byte("allowed_scopes:\n  - {a: [b], c: [d]}\n"), &config); err == nil {
+		t.Error("yaml.Unmarshal() accepted a scope with two names")
+	}
+}
+
+func TestIsScopeAllowed(t *testing.T) {
+	tree := []ScopeNode{
+		{Name: "api", Children: []ScopeNode{{Name: "auth"}}},
+		{Name: "cli"},
+	}
+
+	tests := []struct {
+		scope    string
+		expected bool
+	}{
+		{"api", true},
+		{"api/auth", true},
+		{"cli", true},
+		{"cli/auth", false},
+		{"api/users", false},
+		{"auth", false},
+	}
+
+	for _, tt := range tests {
+		if result := isScopeAllowed(tree, tt.scope); result != tt.expected {
+			t.Errorf("isScopeAllowed(%q) = %v, want %v", tt.scope, result, tt.expected)
+		}
+	}
+}
+
+func TestSplitScopes(t *testing.T) {
+	expected := []scopeElement{
+		{Value: "api/auth", Offset: 5},
+		{Value: "cli", Offset: 15},
+	}
+	if result := splitScopes("api/auth, cli", 5); !reflect.DeepEqual(result, expected) {
+		t.Errorf("splitScopes() = %+v, want %+v", result, expected)
+	}
+}
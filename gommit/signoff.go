@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const signedOffByToken = "Signed-off-by"
+
+var signOffPattern = regexp.MustCompile(`^[^<>]+ <[^<>\s@]+@[^<>\s]+>$`)
+
+// checkSignedOffBy enforces the Developer Certificate of Origin: the message
+// must carry a "Signed-off-by: Name <email>" trailer for the committer
+// identity from git config. When the identity is unknown, only the shape of
+// the trailers is checked.
+func checkSignedOffBy(commit *ParsedCommit, config Config) []Violation {
+	name, email, err := gitIdentity()
+	identity := ""
+	if err == nil && name != "" && email != "" {
+		identity = fmt.Sprintf("%s <%s>", name, email)
+	}
+
+	var violations []Violation
+	signed := false
+	for _, footer := range commit.Footers {
+		if !strings.EqualFold(footer.Token, signedOffByToken) {
+			continue
+		}
+		value := strings.TrimSpace(footer.Value)
+		if !signOffPattern.MatchString(value) {
+			violations = append(violations, Violation{
+				Line: footer.Line, Column: 1, EndColumn: len(commit.Lines[footer.Line-1]) + 1,
+				Message: fmt.Sprintf("%s must be in format: %s: Name <email>", signedOffByToken, signedOffByToken),
+			})
+			continue
+		}
+		if identity == "" || value == identity {
+			signed = true
+		}
+	}
+	if signed {
+		return violations
+	}
+
+	line := len(commit.Lines)
+	v := Violation{
+		Line: line, Column: 1, EndColumn: len(commit.Lines[line-1]) + 1,
+		Message: fmt.Sprintf("Commit must be signed off with '%s: Name <email>'", signedOffByToken),
+	}
+	if identity != "" {
+		v.Message = fmt.Sprintf("Commit must be signed off by the committer with '%s: %s'", signedOffByToken, identity)
+		v.Fix = &Fix{
+			Description: fmt.Sprintf("Append '%s: %s'", signedOffByToken, identity),
+			Apply: func(msg string) string {
+				return appendFooter(msg, signedOffByToken, identity)
+			},
+		}
+	}
+	return append(violations, v)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckSignedOffBy(t *testing.T) {
+	identity := map[string]string{
+		"config user.name":  "Jane Doe",
+		"config user.email": "jane@example.com",
+	}
+
+	tests := []struct {
+		name     string
+		git      map[string]string
+		msg      string
+		expected []string
+		fixed    string
+	}{
+		{
+			name: "Signed off by the committer",
+			git:  identity,
+			msg:  "feat: add parser\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Missing sign-off",
+			git:      identity,
+			msg:      "feat: add parser\n\nRefs: #12",
+			expected: []string{"Commit must be signed off by the committer with 'Signed-off-by: Jane Doe <jane@example.com>'"},
+			fixed:    "feat: add parser\n\nRefs: #12\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Signed off by someone else",
+			git:      identity,
+			msg:      "feat: add parser\n\nSigned-off-by: John Doe <john@example.com>",
+			expected: []string{"Commit must be signed off by the committer with 'Signed-off-by: Jane Doe <jane@example.com>'"},
+			fixed:    "feat: add parser\n\nSigned-off-by: John Doe <john@example.com>\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name: "Malformed sign-off",
+			git:  identity,
+			msg:  "feat: add parser\n\nSigned-off-by: jane\nSigned-off-by: Jane Doe <jane@example.com>",
+			expected: []string{
+				"Signed-off-by must be in format: Signed-off-by: Name <email>",
+			},
+		},
+		{
+			name: "Unknown identity",
+			git:  map[string]string{},
+			msg:  "feat: add parser\n\nSigned-off-by: John Doe <john@example.com>",
+		},
+		{
+			name:     "Unknown identity without sign-off",
+			git:      map[string]string{},
+			msg:      "feat: add parser",
+			expected: []string{"Commit must be signed off with 'Signed-off-by: Name <email>'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubGit(t, tt.git)
+			violations := checkSignedOffBy(parseCommit(tt.msg, ": "), defaultConfig)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkSignedOffBy() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				last := violations[len(violations)-1]
+				if last.Fix == nil {
+					t.Fatal("checkSignedOffBy() violation has no fix")
+				}
+				if result := last.Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	subjectCaseLower    = "lower-case"
+	subjectCaseSentence = "sentence-case"
+	subjectCaseStart    = "start-lower"
+	subjectCaseAny      = "any"
+)
+
+var subjectCaseModes = []string{subjectCaseLower, subjectCaseSentence, subjectCaseStart, subjectCaseAny}
+
+// subjectWord is a word of the description with its byte offset in the
+// header, stripped of surrounding punctuation.
+type subjectWord struct {
+	Value  string
+	Offset int
+}
+
+func subjectWords(commit *ParsedCommit) []subjectWord {
+	var words []subjectWord
+	description := commit.Description
+	for i := 0; i < len(description); {
+		if description[i] == ' ' {
+			i++
+			continue
+		}
+		end := strings.IndexByte(description[i:], ' ')
+		if end < 0 {
+			end = len(description)
+		} else {
+			end += i
+		}
+		field := description[i:end]
+		trimmed := strings.TrimLeftFunc(field, isWordPunctuation)
+		start := i + len(field) - len(trimmed)
+		if value := strings.TrimRightFunc(trimmed, isWordPunctuation); value != "" {
+			words = append(words, subjectWord{Value: value, Offset: commit.DescriptionOffset + start})
+		}
+		i = end
+	}
+	return words
+}
+
+func isWordPunctuation(r rune) bool {
+	return unicode.IsPunct(r) && r != '-' && r != '_'
+}
+
+// isExemptWord reports whether word matches one of the subject_case_exceptions,
+// each of which is a word or a regular expression matched against the whole
+// word.
+func isExemptWord(word string, exceptions []string) bool {
+	for _, exception := range exceptions {
+		if pattern, err := regexp.Compile(`^(?:` + exception + `)$`); err == nil && pattern.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func startsUpper(word string) bool {
+	first, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(first) || unicode.IsTitle(first)
+}
+
+func checkSubjectCase(commit *ParsedCommit, config Config) []Violation {
+	words := subjectWords(commit)
+	if len(words) == 0 {
+		return nil
+	}
+	exceptions := config.SubjectCaseExceptions
+
+	switch config.SubjectCase {
+	case subjectCaseAny:
+		return nil
+	case subjectCaseStart:
+		if startsUpper(words[0].Value) && !isExemptWord(words[0].Value, exceptions) {
+			return []Violation{subjectCaseViolation(commit, words[0], "Subject must start with a lowercase letter", strings.ToLower)}
+		}
+		return nil
+	case subjectCaseSentence:
+		if !startsUpper(words[0].Value) && !isExemptWord(words[0].Value, exceptions) {
+			return []Violation{subjectCaseViolation(commit, words[0], "Subject must be in sentence case", capitalizeFirst)}
+		}
+		return lowercaseWordViolations(commit, words[1:], exceptions, "Subject must be in sentence case")
+	default:
+		return lowercaseWordViolations(commit, words, exceptions, "Subject must be in lowercase")
+	}
+}
+
+func lowercaseWordViolations(commit *ParsedCommit, words []subjectWord, exceptions []string, message string) []Violation {
+	var violations []Violation
+	for _, word := range words {
+		if strings.ToLower(word.Value) != word.Value && !isExemptWord(word.Value, exceptions) {
+			violations = append(violations, subjectCaseViolation(commit, word, message, strings.ToLower))
+		}
+	}
+	return violations
+}
+
+func subjectCaseViolation(commit *ParsedCommit, word subjectWord, message string, convert func(string) string) Violation {
+	replacement := convert(word.Value)
+	fixed := commit.Header[:word.Offset] + replacement + commit.Header[word.Offset+len(word.Value):]
+	return Violation{
+		Line: 1, Column: word.Offset + 1, EndColumn: word.Offset + len(word.Value) + 1,
+		Message: fmt.Sprintf("%s (found '%s')", message, word.Value),
+		Fix: &Fix{
+			Description: fmt.Sprintf("Change '%s' to '%s'", word.Value, replacement),
+			Apply: func(msg string) string {
+				return replaceLine(msg, 1, fixed)
+			},
+		},
+	}
+}
+
+func capitalizeFirst(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + word[size:]
+}
+
+func validateSubjectCase(config Config) error {
+	if !contains(subjectCaseModes, config.SubjectCase) {
+		return fmt.Errorf("invalid subject_case %q (expected %s)", config.SubjectCase, strings.Join(subjectCaseModes, ", "))
+	}
+	for _, exception := range config.SubjectCaseExceptions {
+		if _, err := regexp.Compile(exception); err != nil {
+			return fmt.Errorf("invalid subject_case_exceptions entry %q: %w", exception, err)
+		}
+	}
+	return nil
+}
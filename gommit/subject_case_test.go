@@ -0,0 +1,167 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckSubjectCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        string
+		mode       string
+		exceptions []string
+		expected   []string
+		fixed      string
+	}{
+		{
+			name: "Lowercase subject",
+			msg:  "feat: add parser",
+			mode: subjectCaseLower,
+		},
+		{
+			name:     "Uppercase word",
+			msg:      "feat: add JSON output",
+			mode:     subjectCaseLower,
+			expected: []string{"Subject must be in lowercase (found 'JSON')"},
+			fixed:    "feat: add json output",
+		},
+		{
+			name:       "Exempt word",
+			msg:        "feat: add JSON output for GitHub",
+			mode:       subjectCaseLower,
+			exceptions: []string{"JSON", "Git[A-Z][a-z]+"},
+		},
+		{
+			name:       "Exemptions match whole words",
+			msg:        "feat: add JSONL output",
+			mode:       subjectCaseLower,
+			exceptions: []string{"JSON"},
+			expected:   []string{"Subject must be in lowercase (found 'JSONL')"},
+		},
+		{
+			name: "Punctuation around words",
+			msg:  "fix: handle (iOS) devices",
+			mode: subjectCaseLower,
+			expected: []string{
+				"Subject must be in lowercase (found 'iOS')",
+			},
+			fixed: "fix: handle (ios) devices",
+		},
+		{
+			name: "Sentence case",
+			msg:  "feat: Add parser",
+			mode: subjectCaseSentence,
+		},
+		{
+			name:     "Sentence case with a lowercase start",
+			msg:      "feat: add parser",
+			mode:     subjectCaseSentence,
+			expected: []string{"Subject must be in sentence case (found 'add')"},
+			fixed:    "feat: Add parser",
+		},
+		{
+			name:     "Sentence case with a capitalised word",
+			msg:      "feat: Add Parser",
+			mode:     subjectCaseSentence,
+			expected: []string{"Subject must be in sentence case (found 'Parser')"},
+			fixed:    "feat: Add parser",
+		},
+		{
+			name:       "Sentence case starting with an exempt word",
+			msg:        "fix: iOS crash on launch",
+			mode:       subjectCaseSentence,
+			exceptions: []string{"iOS"},
+		},
+		{
+			name: "Start lower allows capitalised words",
+			msg:  "feat: add Parser for YAML",
+			mode: subjectCaseStart,
+		},
+		{
+			name:     "Start lower with an uppercase start",
+			msg:      "feat: Add parser",
+			mode:     subjectCaseStart,
+			expected: []string{"Subject must start with a lowercase letter (found 'Add')"},
+			fixed:    "feat: add parser",
+		},
+		{
+			name: "Any case",
+			msg:  "feat: ADD Parser",
+			mode: subjectCaseAny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig
+			config.SubjectCase = tt.mode
+			config.SubjectCaseExceptions = tt.exceptions
+			violations := checkSubjectCase(parseCommit(tt.msg, ": "), config)
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.expected) {
+				t.Errorf("checkSubjectCase() = %v, want %v", messages, tt.expected)
+			}
+			if tt.fixed != "" {
+				if violations[0].Fix == nil {
+					t.Fatal("checkSubjectCase() violation has no fix")
+				}
+				if result := violations[0].Fix.Apply(tt.msg); result != tt.fixed {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.fixed)
+				}
+			}
+		})
+	}
+}
+
+func TestDescriptionCaseFollowsSubjectCase(t *testing.T) {
+	config := defaultConfig
+	config.SubjectCase = subjectCaseSentence
+	if violations := checkDescriptionCase(parseCommit("feat: Add parser", ": "), config); len(violations) != 0 {
+		t.Errorf("checkDescriptionCase() in sentence case = %v, want none", violations)
+	}
+
+	config = defaultConfig
+	if violations := validateCommitMsg("feat: Add parser", config); len(violations) != 1 || violations[0].Rule != "subject-case" {
+		t.Errorf("validateCommitMsg() in lower case = %v, want a single subject-case violation", violations)
+	}
+
+	config = defaultConfig
+	config.SubjectCase = subjectCaseAny
+	config.SubjectCaseExceptions = []string{"GraphQL"}
+	if violations := checkDescriptionCase(parseCommit("feat: GraphQL schema", ": "), config); len(violations) != 0 {
+		t.Errorf("checkDescriptionCase() with an exempt first word = %v, want none", violations)
+	}
+	if violations := checkDescriptionCase(parseCommit("feat: Add parser", ": "), config); len(violations) != 1 {
+		t.Errorf("checkDescriptionCase() in any case = %v, want one violation", violations)
+	}
+
+	config = defaultConfig
+	config.Rules = map[string]Severity{"subject-case": SeverityOff}
+	if violations := checkDescriptionCase(parseCommit("feat: Add parser", ": "), config); len(violations) != 1 {
+		t.Errorf("checkDescriptionCase() with subject-case off = %v, want one violation", violations)
+	}
+}
+
+func TestValidateSubjectCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "Default", config: defaultConfig},
+		{name: "Unknown mode", config: Config{SubjectCase: "title-case"}, wantErr: true},
+		{name: "Invalid exception", config: Config{SubjectCase: subjectCaseLower, SubjectCaseExceptions: []string{"("}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSubjectCase(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("validateSubjectCase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import "strings"
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// closestMatch returns the candidate closest to value, provided it is close
+// enough to be a plausible typo and no other candidate is equally close.
+// Failing that, it falls back to the only candidate value abbreviates or
+// extends, such as "feat" for "feature".
+func closestMatch(value string, candidates []string) (string, bool) {
+	if match, ok := editDistanceMatch(value, candidates); ok {
+		return match, true
+	}
+	return prefixMatch(value, candidates)
+}
+
+// editDistanceMatch allows one edit for values shorter than five characters,
+// so that "foo" is not taken for "fix", and a third of the length, but at
+// least two, for longer ones.
+func editDistanceMatch(value string, candidates []string) (string, bool) {
+	maxDistance := max(2, len([]rune(value))/3)
+	if len([]rune(value)) < 5 {
+		maxDistance = 1
+	}
+	return editDistanceMatchWithin(value, candidates, maxDistance)
+}
+
+func editDistanceMatchWithin(value string, candidates []string, maxDistance int) (string, bool) {
+	best, bestDistance, ties := "", maxDistance+1, 0
+	for _, candidate := range candidates {
+		d := levenshtein(value, candidate)
+		switch {
+		case d < bestDistance:
+			best, bestDistance, ties = candidate, d, 0
+		case d == bestDistance:
+			ties++
+		}
+	}
+	if best == "" || ties > 0 {
+		return "", false
+	}
+	return best, true
+}
+
+// prefixMatch returns the only candidate that is a prefix of value or that
+// value is a prefix of. Prefixes shorter than three characters are ignored.
+func prefixMatch(value string, candidates []string) (string, bool) {
+	var matches []string
+	for _, candidate := range candidates {
+		shorter, longer := candidate, value
+		if len(value) < len(candidate) {
+			shorter, longer = value, candidate
+		}
+		if len(shorter) >= 3 && shorter != longer && strings.HasPrefix(longer, shorter) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
+}
@@ -0,0 +1,100 @@
+package main
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"api", "api", 0},
+		{"apii", "api", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if result := levenshtein(tt.a, tt.b); result != tt.expected {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		candidates []string
+		expected   string
+		found      bool
+	}{
+		{
+			name:       "Single close candidate",
+			value:      "apii",
+			candidates: []string{"api", "cli", "docs"},
+			expected:   "api",
+			found:      true,
+		},
+		{
+			name:       "Nothing close enough",
+			value:      "database",
+			candidates: []string{"api", "cli"},
+			found:      false,
+		},
+		{
+			name:       "Abbreviated candidate",
+			value:      "feature",
+			candidates: []string{"feat", "fix", "docs"},
+			expected:   "feat",
+			found:      true,
+		},
+		{
+			name:       "Extended candidate",
+			value:      "refac",
+			candidates: []string{"refactor", "revert"},
+			expected:   "refactor",
+			found:      true,
+		},
+		{
+			name:       "Ambiguous prefix",
+			value:      "perform",
+			candidates: []string{"perf", "performance"},
+			found:      false,
+		},
+		{
+			name:       "Short typo",
+			value:      "fiz",
+			candidates: []string{"feat", "fix", "docs"},
+			expected:   "fix",
+			found:      true,
+		},
+		{
+			name:       "Short unrelated value",
+			value:      "foo",
+			candidates: []string{"feat", "fix", "docs", "test"},
+			found:      false,
+		},
+		{
+			name:       "Short unrelated value two edits away",
+			value:      "just",
+			candidates: []string{"feat", "fix", "docs", "test"},
+			found:      false,
+		},
+		{
+			name:       "Ambiguous candidates",
+			value:      "ab",
+			candidates: []string{"aa", "bb"},
+			found:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := closestMatch(tt.value, tt.candidates)
+			if result != tt.expected || found != tt.found {
+				t.Errorf("closestMatch() = %q, %v, want %q, %v", result, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityOff     Severity = "off"
+)
+
+func (s Severity) valid() bool {
+	return s == SeverityError || s == SeverityWarning || s == SeverityOff
+}
+
+// Fix is a deterministic edit that resolves a violation. Apply receives the
+// trimmed commit message the violation was reported against and returns the
+// corrected message.
+type Fix struct {
+	Description string                  `json:"description"`
+	Apply       func(msg string) string `json:"-"`
+}
+
+// Violation is a single rule failure. Line and Column are 1-based and
+// EndColumn is exclusive, so a violation covering a whole header spans
+// Column 1 to len(header)+1.
+type Violation struct {
+	Rule      string   `json:"rule"`
+	Severity  Severity `json:"severity"`
+	Line      int      `json:"line"`
+	Column    int      `json:"column"`
+	EndColumn int      `json:"end_column"`
+	Message   string   `json:"message"`
+	Fix       *Fix     `json:"fix,omitempty"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", v.Line, v.Column, v.Message, v.Rule)
+}
+
+func formatViolation(v Violation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  • %s [%s, line %d]", v.Message, v.Rule, v.Line)
+	if v.Fix != nil {
+		fmt.Fprintf(&b, "\n    ↳ suggested fix: %s", v.Fix.Description)
+	}
+	return b.String()
+}
+
+func violationStyle(v Violation) lipgloss.Style {
+	if v.Severity == SeverityWarning {
+		return warningStyle
+	}
+	return detailStyle
+}
+
+func hasErrors(violations []Violation) bool {
+	return len(filterSeverity(violations, SeverityError)) > 0
+}
+
+func filterSeverity(violations []Violation, severity Severity) []Violation {
+	var filtered []Violation
+	for _, v := range violations {
+		if v.Severity == severity {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+func hasViolation(violations []Violation, ruleName string) bool {
+	for _, v := range violations {
+		if v.Rule == ruleName {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceLine returns msg with the given 1-based line replaced.
+func replaceLine(msg string, line int, content string) string {
+	lines := strings.Split(msg, "\n")
+	if line < 1 || line > len(lines) {
+		return msg
+	}
+	lines[line-1] = content
+	return strings.Join(lines, "\n")
+}
+
+// insertLine returns msg with content inserted before the given 1-based line.
+func insertLine(msg string, line int, content string) string {
+	lines := strings.Split(msg, "\n")
+	if line < 1 || line > len(lines)+1 {
+		return msg
+	}
+	lines = append(lines[:line-1], append([]string{content}, lines[line-1:]...)...)
+	return strings.Join(lines, "\n")
+}
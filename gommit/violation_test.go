@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestViolationPositions(t *testing.T) {
+	config := defaultConfig
+
+	tests := []struct {
+		name      string
+		msg       string
+		rule      string
+		line      int
+		column    int
+		endColumn int
+	}{
+		{
+			name:      "Header too long",
+			msg:       "feat: this header is way too long and exceeds the maximum length",
+			rule:      "header-max-length",
+			line:      1,
+			column:    51,
+			endColumn: 65,
+		},
+		{
+			name:      "Uppercase scope",
+			msg:       "feat(API): add endpoint",
+			rule:      "scope-case",
+			line:      1,
+			column:    6,
+			endColumn: 9,
+		},
+		{
+			name:      "Long body line",
+			msg:       "feat: add feature\n\n" + strings.Repeat("a", 80),
+			rule:      "body-line-max-length",
+			line:      3,
+			column:    73,
+			endColumn: 81,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range validateCommitMsg(tt.msg, config) {
+				if v.Rule != tt.rule {
+					continue
+				}
+				if v.Line != tt.line || v.Column != tt.column || v.EndColumn != tt.endColumn {
+					t.Errorf("%s position = %d:%d-%d, want %d:%d-%d", tt.rule, v.Line, v.Column, v.EndColumn, tt.line, tt.column, tt.endColumn)
+				}
+				if v.Severity != SeverityError {
+					t.Errorf("%s severity = %v, want %v", tt.rule, v.Severity, SeverityError)
+				}
+				return
+			}
+			t.Errorf("no %s violation reported", tt.rule)
+		})
+	}
+}
+
+func TestViolationFix(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		rule     string
+		expected string
+	}{
+		{
+			name:     "Lowercase type",
+			msg:      "FEAT: add feature",
+			rule:     "type-case",
+			expected: "feat: add feature",
+		},
+		{
+			name:     "Lowercase description",
+			msg:      "feat: Add feature\n\nBody",
+			rule:     "subject-case",
+			expected: "feat: add feature\n\nBody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range validateCommitMsg(tt.msg, defaultConfig) {
+				if v.Rule != tt.rule {
+					continue
+				}
+				if v.Fix == nil {
+					t.Fatalf("%s violation has no fix", tt.rule)
+				}
+				if result := v.Fix.Apply(tt.msg); result != tt.expected {
+					t.Errorf("Fix.Apply() = %q, want %q", result, tt.expected)
+				}
+				return
+			}
+			t.Errorf("no %s violation reported", tt.rule)
+		})
+	}
+}
+
+func TestHasViolation(t *testing.T) {
+	violations := []Violation{{Rule: "type-enum"}, {Rule: "breaking-change"}}
+
+	if !hasViolation(violations, "breaking-change") {
+		t.Error("hasViolation() = false, want true")
+	}
+	if hasViolation(violations, "header-format") {
+		t.Error("hasViolation() = true, want false")
+	}
+}
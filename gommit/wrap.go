@@ -0,0 +1,166 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+type bodyBlockKind int
+
+const (
+	blockBlank bodyBlockKind = iota
+	blockProse
+	blockList
+	blockCode
+	blockTable
+)
+
+// bodyBlock is a run of body lines of the same kind. Start is the index of
+// its first line in the body lines.
+type bodyBlock struct {
+	Kind  bodyBlockKind
+	Start int
+	Lines []string
+}
+
+func (b bodyBlock) wrappable() bool {
+	return b.Kind == blockProse || b.Kind == blockList
+}
+
+var (
+	fencePattern    = regexp.MustCompile("^\\s*(```|~~~)")
+	listItemPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+	urlPattern      = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://\S+`)
+)
+
+func isIndentedCode(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// bodyBlocks splits body lines into Markdown-ish blocks: fenced code,
+// indented code, tables, list items with their hanging indent, and prose
+// paragraphs.
+func bodyBlocks(lines []string) []bodyBlock {
+	var blocks []bodyBlock
+	start := func(kind bodyBlockKind, i int) {
+		blocks = append(blocks, bodyBlock{Kind: kind, Start: i})
+	}
+	current := func() *bodyBlock {
+		if len(blocks) == 0 {
+			return &bodyBlock{Kind: blockBlank}
+		}
+		return &blocks[len(blocks)-1]
+	}
+
+	inFence := false
+	for i, line := range lines {
+		switch {
+		case inFence:
+			if fencePattern.MatchString(line) {
+				inFence = false
+			}
+		case fencePattern.MatchString(line):
+			inFence = true
+			start(blockCode, i)
+		case strings.TrimSpace(line) == "":
+			start(blockBlank, i)
+		case strings.HasPrefix(strings.TrimSpace(line), "|"):
+			if current().Kind != blockTable {
+				start(blockTable, i)
+			}
+		case listItemPattern.MatchString(line):
+			start(blockList, i)
+		case current().Kind == blockList && strings.HasPrefix(line, " "):
+			// Hanging indent of the current list item.
+		case current().Kind == blockProse:
+			// Indented code cannot interrupt a paragraph.
+		case isIndentedCode(line):
+			if current().Kind != blockCode {
+				start(blockCode, i)
+			}
+		default:
+			start(blockProse, i)
+		}
+		b := current()
+		b.Lines = append(b.Lines, line)
+	}
+	return blocks
+}
+
+// blockAt returns the block holding the line at index i of the body lines.
+func blockAt(blocks []bodyBlock, i int) bodyBlock {
+	for _, b := range blocks {
+		if i >= b.Start && i < b.Start+len(b.Lines) {
+			return b
+		}
+	}
+	return bodyBlock{}
+}
+
+// isLineLengthExempt reports whether a body line is exempt from
+// body-line-max-length: code when body_line_ignore_code is set, and lines
+// holding a URL when body_line_ignore_urls is set.
+func isLineLengthExempt(line string, block bodyBlock, config Config) bool {
+	if config.BodyLineIgnoreCode && block.Kind == blockCode {
+		return true
+	}
+	return config.BodyLineIgnoreURLs && urlPattern.MatchString(line)
+}
+
+// rewrapBody rewraps the prose paragraphs and list items of the body that
+// have a line longer than body_line_max_length. Code, tables, blank lines and
+// footers are left untouched, and words, including URLs, are never split.
+func rewrapBody(commit *ParsedCommit, config Config) string {
+	lines := bodyLines(commit)
+	var body []string
+	for _, b := range bodyBlocks(lines) {
+		if !b.wrappable() || !blockTooLong(b, config) {
+			body = append(body, b.Lines...)
+			continue
+		}
+		first, rest := "", ""
+		if b.Kind == blockList {
+			first = listItemPattern.FindString(b.Lines[0])
+			rest = strings.Repeat(" ", len(first))
+		} else {
+			first = b.Lines[0][:len(b.Lines[0])-len(strings.TrimLeft(b.Lines[0], " \t"))]
+			rest = first
+		}
+		text := strings.Join(b.Lines, " ")[len(first):]
+		body = append(body, wrapWords(strings.Fields(text), first, rest, config)...)
+	}
+
+	result := append([]string{commit.Header}, body...)
+	result = append(result, commit.Lines[1+len(lines):]...)
+	return strings.Join(result, "\n")
+}
+
+func blockTooLong(b bodyBlock, config Config) bool {
+	for _, line := range b.Lines {
+		if textLength(line, config.LengthUnit) > config.BodyLineMaxLength && !isLineLengthExempt(line, b, config) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapWords fills lines greedily up to body_line_max_length, starting the
+// first line with first and the following ones with rest. A word longer than
+// the limit gets a line of its own.
+func wrapWords(words []string, first, rest string, config Config) []string {
+	var lines []string
+	line := first
+	empty := true
+	for _, word := range words {
+		if !empty && textLength(line+" "+word, config.LengthUnit) > config.BodyLineMaxLength {
+			lines = append(lines, line)
+			line, empty = rest, true
+		}
+		if !empty {
+			line += " "
+		}
+		line += word
+		empty = false
+	}
+	return append(lines, line)
+}
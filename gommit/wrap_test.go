@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBodyBlocks(t *testing.T) {
+	lines := strings.Split("Some prose\nwraps here.\n\n- item one\n  continues\n- item two\n\n```\ncode line\n```\n\n    indented code\n\n| a | b |\n| - | - |", "\n")
+	var kinds []bodyBlockKind
+	for _, b := range bodyBlocks(lines) {
+		kinds = append(kinds, b.Kind)
+	}
+	expected := []bodyBlockKind{
+		blockProse, blockBlank, blockList, blockList, blockBlank,
+		blockCode, blockBlank, blockCode, blockBlank, blockTable,
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("bodyBlocks() kinds = %v, want %v", kinds, expected)
+	}
+}
+
+func TestRewrapBody(t *testing.T) {
+	config := defaultConfig
+	config.BodyLineMaxLength = 30
+
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{
+			name:     "Prose paragraph",
+			msg:      "fix: handle nil\n\nThe parser crashed when the configuration file was empty.\n\nRefs: #12",
+			expected: "fix: handle nil\n\nThe parser crashed when the\nconfiguration file was empty.\n\nRefs: #12",
+		},
+		{
+			name:     "Short paragraphs keep their line breaks",
+			msg:      "fix: handle nil\n\nShort line.\nAnother one.\n\nThis paragraph is a little bit too long.",
+			expected: "fix: handle nil\n\nShort line.\nAnother one.\n\nThis paragraph is a little bit\ntoo long.",
+		},
+		{
+			name:     "List item with hanging indent",
+			msg:      "feat: add flags\n\n- add the --fix flag to the check command\n- add --quiet",
+			expected: "feat: add flags\n\n- add the --fix flag to the\n  check command\n- add --quiet",
+		},
+		{
+			name:     "Code and URLs are left alone",
+			msg:      "fix: handle nil\n\n```\npanic: runtime error: invalid memory address\n```\n\nSee https://example.com/a/very/long/path/to/the/issue",
+			expected: "fix: handle nil\n\n```\npanic: runtime error: invalid memory address\n```\n\nSee https://example.com/a/very/long/path/to/the/issue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rewrapBody(parseCommit(tt.msg, ": "), config); result != tt.expected {
+				t.Errorf("rewrapBody() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckBodyLineMaxLengthExemptions(t *testing.T) {
+	msg := "fix: handle nil\n\n" +
+		"    panic: runtime error: invalid memory address or nil pointer dereference\n\n" +
+		"See https://github.com/moukrea/gommit/issues/12#issuecomment-1234567890 for details.\n\n" +
+		"This line of prose is definitely longer than the seventy-two characters allowed."
+
+	violations := checkBodyLineMaxLength(parseCommit(msg, ": "), defaultConfig)
+	if len(violations) != 1 || violations[0].Line != 7 || violations[0].Fix == nil {
+		t.Fatalf("checkBodyLineMaxLength() = %v, want a fixable violation on line 7", violations)
+	}
+	expected := "fix: handle nil\n\n" +
+		"    panic: runtime error: invalid memory address or nil pointer dereference\n\n" +
+		"See https://github.com/moukrea/gommit/issues/12#issuecomment-1234567890 for details.\n\n" +
+		"This line of prose is definitely longer than the seventy-two characters\nallowed."
+	if result := violations[0].Fix.Apply(msg); result != expected {
+		t.Errorf("Fix.Apply() = %q, want %q", result, expected)
+	}
+
+	config := defaultConfig
+	config.BodyLineIgnoreURLs = false
+	config.BodyLineIgnoreCode = false
+	if violations := checkBodyLineMaxLength(parseCommit(msg, ": "), config); len(violations) != 3 {
+		t.Errorf("checkBodyLineMaxLength() without exemptions = %v, want 3 violations", violations)
+	}
+}